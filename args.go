@@ -7,7 +7,7 @@ import (
 	"unicode/utf8"
 )
 
-// NamedArg is a named argument. NamedArg values may be used as
+// NamedArg is a named argument. NamedArg values, or pointers to them, may be used as
 // arguments to [Connection.Query] or [Connection.QueryRow] or [Connection.Exec]
 // and bind to the corresponding named parameter in the SQL statement.
 //
@@ -66,7 +66,7 @@ func getDriverNamedValuesFromArgs(c *Connection, args []any) ([]driver.NamedValu
 	n := 0
 	for _, a := range args {
 		nv := &nvs[n]
-		if np, ok := a.(NamedArg); ok {
+		if np, ok := getNamedArg(a); ok {
 			if err := validateNamedValueName(np.Name); err != nil {
 				return nil, err
 			}
@@ -101,6 +101,18 @@ func getDriverNamedValuesFromArgs(c *Connection, args []any) ([]driver.NamedValu
 	return nvs, nil
 }
 
+func getNamedArg(a any) (NamedArg, bool) {
+	switch np := a.(type) {
+	case NamedArg:
+		return np, true
+	case *NamedArg:
+		if np != nil {
+			return *np, true
+		}
+	}
+	return NamedArg{}, false
+}
+
 func getDriverValueFromDriverNamedValue(nvs []driver.NamedValue) ([]driver.Value, error) {
 	vs := make([]driver.Value, len(nvs))
 	for i, v := range nvs {
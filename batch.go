@@ -1,14 +1,24 @@
 package alphasql
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"sort"
+)
 
 // BatchRemove can be called to remove the corresponding queued operation from the batch.
-// It is a noop if the batch is already processing([Batch.Do] has already been called) or
-// batch is already closed([Batch.Close] has already been called).
+// It is a noop if the batch is already processing([Batch.Do] has already been called),
+// batch is already closed([Batch.Close] has already been called) or the operation was already
+// executed by [Batch.Flush], never removing an operation queued afterwards.
 type BatchRemove func()
 
 // BatchConfig is used as the set of configurations for batch.
-type BatchConfig struct{}
+type BatchConfig struct {
+	// StopOnError makes the operations following a failed one fail with [ErrBatchAborted] without being executed.
+	// Otherwise, every operation is executed regardless of the failures of the previous ones, which suits the
+	// idempotent writes not needing atomicity, the failed ones being retried using [BatchResults.Errors].
+	StopOnError bool
+}
 
 // Batch is used as the set of functionalities for a batch operation on the database.
 type Batch interface {
@@ -32,13 +42,45 @@ type Batch interface {
 	// It returns the result as an iterator, providing specific methods for all the above operations.
 	Do(ctx context.Context) (BatchResults, error)
 
+	// Flush is used to execute the queries queued so far, removing them from the batch while keeping it open,
+	// so more queries can be queued afterwards, saving the memory of buffering very large batches until Do.
+	// The results must be closed before calling Flush or Do again, and closing them does not close the batch.
+	Flush(ctx context.Context) (BatchResults, error)
+
 	// Close is used to close the batch, releasing the connection(making it available for use somewhere else).
 	// If Close is called before Do, then all the queued queries will be removed.
 	Close(ctx context.Context) error
 }
 
 // BatchResults is used as the results for the batch.
-type BatchResults interface{}
+//
+// Each call to one of the accessors executes the next queued operation, in the order they were queued,
+// and returns its result. The accessor called must match the mode of the operation, otherwise
+// [ErrBatchOperationModeMismatch] is returned and the operation is skipped. Once all the operations
+// have been consumed, [ErrBatchNoMoreResults] is returned.
+//
+// The [Rows] returned by [BatchResults.QueryResults] must be consumed and closed before calling
+// the next accessor, as the operations share the same connection.
+type BatchResults interface {
+	// QueryResults returns the rows of the next operation, queued with [Batch.QueueQuery].
+	QueryResults() (Rows, error)
+
+	// QueryRow returns the row of the next operation, queued with [Batch.QueueQueryRow].
+	// Like [Connection.QueryRow], it always returns a non-nil value, with errors deferred until [Row.Scan] is called.
+	QueryRow() Row
+
+	// Exec returns the result of the next operation, queued with [Batch.QueueExec].
+	Exec() (Result, error)
+
+	// Errors returns the errors of the operations consumed so far, in the order they were queued, with a nil error
+	// for each operation that succeeded, so the failed ones can be told apart and retried. The errors deferred until
+	// [Row.Scan] is called or returned while iterating the [Rows] are not included.
+	Errors() []error
+
+	// Close closes the results, skipping the operations not consumed yet. Unless the results were returned
+	// by [Batch.Flush], it also closes the batch.
+	Close(ctx context.Context) error
+}
 
 // BatchOperationMode is used to specify the type of the operation.
 type BatchOperationMode string
@@ -63,8 +105,11 @@ type batch struct {
 	baseCtx       context.Context
 	cancelBaseCtx context.CancelFunc
 	operations    map[int]batchOperation
-	processing    bool
-	closed        bool
+	// nextID is the id of the next operation queued, increasing for the lifetime of the batch, so the ids are never
+	// reused, even after the operations are removed or flushed.
+	nextID     int
+	processing bool
+	closed     bool
 }
 
 // NewBatch is used to provide a way to batch queries to save round-trip.
@@ -80,9 +125,10 @@ func (c *Connection) NewBatch(ctx context.Context, cfg *BatchConfig) (Batch, err
 }
 
 func (b *batch) QueueQuery(_ context.Context, query string, args ...any) BatchRemove {
-	id := len(b.operations)
+	id := b.nextID
+	b.nextID++
 	b.operations[id] = batchOperation{
-		id:    len(b.operations),
+		id:    id,
 		mode:  BatchOperationModeQuery,
 		query: query,
 		args:  args,
@@ -91,9 +137,10 @@ func (b *batch) QueueQuery(_ context.Context, query string, args ...any) BatchRe
 }
 
 func (b *batch) QueueQueryRow(_ context.Context, query string, args ...any) BatchRemove {
-	id := len(b.operations)
+	id := b.nextID
+	b.nextID++
 	b.operations[id] = batchOperation{
-		id:    len(b.operations),
+		id:    id,
 		mode:  BatchOperationModeQueryRow,
 		query: query,
 		args:  args,
@@ -102,9 +149,10 @@ func (b *batch) QueueQueryRow(_ context.Context, query string, args ...any) Batc
 }
 
 func (b *batch) QueueExec(_ context.Context, query string, args ...any) BatchRemove {
-	id := len(b.operations)
+	id := b.nextID
+	b.nextID++
 	b.operations[id] = batchOperation{
-		id:    len(b.operations),
+		id:    id,
 		mode:  BatchOperationModeExec,
 		query: query,
 		args:  args,
@@ -119,7 +167,30 @@ func (b *batch) Do(_ context.Context) (BatchResults, error) {
 	if b.closed {
 		return nil, ErrBatchClosed
 	}
-	return nil, nil
+	b.processing = true
+	return &batchResults{b: b, operations: b.takeOperations(), closesBatch: true}, nil
+}
+
+func (b *batch) Flush(_ context.Context) (BatchResults, error) {
+	if b.processing {
+		return nil, ErrBatchProcessing
+	}
+	if b.closed {
+		return nil, ErrBatchClosed
+	}
+	b.processing = true
+	return &batchResults{b: b, operations: b.takeOperations()}, nil
+}
+
+// takeOperations removes all the queued operations from the batch, returning them in the order they were queued.
+func (b *batch) takeOperations() []batchOperation {
+	operations := make([]batchOperation, 0, len(b.operations))
+	for _, o := range b.operations {
+		operations = append(operations, o)
+	}
+	sort.Slice(operations, func(i, j int) bool { return operations[i].id < operations[j].id })
+	b.operations = make(map[int]batchOperation)
+	return operations
 }
 
 func (b *batch) Close(_ context.Context) error {
@@ -138,6 +209,94 @@ func (b *batch) getBatchRemoveForID(id int) BatchRemove {
 		if b.processing || b.closed {
 			return
 		}
+		// the ids are never reused, so the removers of the flushed operations do not match any queued one
 		delete(b.operations, id)
 	}
 }
+
+type batchResults struct {
+	b          *batch
+	operations []batchOperation
+	closed     bool
+
+	// errs are the errors of the operations consumed, and failed the first of them not being nil.
+	errs   []error
+	failed error
+
+	// closesBatch is set for the results of Do, closing the batch along with them.
+	closesBatch bool
+}
+
+func (r *batchResults) QueryResults() (Rows, error) {
+	o, err := r.next(BatchOperationModeQuery)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.b.c.Query(r.b.baseCtx, o.query, o.args...)
+	return rows, r.record(err)
+}
+
+func (r *batchResults) QueryRow() Row {
+	o, err := r.next(BatchOperationModeQueryRow)
+	if err != nil {
+		return &row{err: err}
+	}
+	rw := r.b.c.QueryRow(r.b.baseCtx, o.query, o.args...)
+	_ = r.record(rw.Error())
+	return rw
+}
+
+func (r *batchResults) Exec() (Result, error) {
+	o, err := r.next(BatchOperationModeExec)
+	if err != nil {
+		return nil, err
+	}
+	res, err := r.b.c.Exec(r.b.baseCtx, o.query, o.args...)
+	return res, r.record(err)
+}
+
+func (r *batchResults) Errors() []error {
+	return append([]error(nil), r.errs...)
+}
+
+func (r *batchResults) Close(_ context.Context) error {
+	if r.closed {
+		return ErrBatchClosed
+	}
+	r.closed = true
+	r.operations = nil
+	r.b.processing = false
+	if r.closesBatch {
+		r.b.closed = true
+		r.b.cancelBaseCtx()
+	}
+	return nil
+}
+
+func (r *batchResults) next(mode BatchOperationMode) (batchOperation, error) {
+	if r.closed {
+		return batchOperation{}, ErrBatchClosed
+	}
+	if len(r.operations) == 0 {
+		return batchOperation{}, ErrBatchNoMoreResults
+	}
+	o := r.operations[0]
+	r.operations = r.operations[1:]
+	if o.mode != mode {
+		return batchOperation{}, r.record(fmt.Errorf("%w: operation %d is %s, not %s",
+			ErrBatchOperationModeMismatch, o.id, o.mode, mode))
+	}
+	if r.failed != nil && r.b.cfg != nil && r.b.cfg.StopOnError {
+		return batchOperation{}, r.record(fmt.Errorf("%w: operation %d: %w", ErrBatchAborted, o.id, r.failed))
+	}
+	return o, nil
+}
+
+// record records the error of the operation consumed last, returning it.
+func (r *batchResults) record(err error) error {
+	r.errs = append(r.errs, err)
+	if err != nil && r.failed == nil {
+		r.failed = err
+	}
+	return err
+}
@@ -0,0 +1,298 @@
+package alphasql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+)
+
+// batchDriver records the queries and the executions run by the batches, answering the queries with their own
+// text and failing the ones listed in fail.
+type batchDriver struct {
+	mu   sync.Mutex
+	runs []string
+	fail map[string]error
+}
+
+func (b *batchDriver) run(query string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.runs = append(b.runs, query)
+	return b.fail[query]
+}
+
+func (b *batchDriver) driver() *fakedriver.Driver {
+	return &fakedriver.Driver{
+		Query: func(_ context.Context, _ *fakedriver.Conn, query string, _ []driver.NamedValue) (driver.Rows, error) {
+			if err := b.run(query); err != nil {
+				return nil, err
+			}
+			return fakedriver.NewRows([]string{"query"}, []driver.Value{query}), nil
+		},
+		Exec: func(_ context.Context, _ *fakedriver.Conn, query string, _ []driver.NamedValue) (driver.Result, error) {
+			if err := b.run(query); err != nil {
+				return nil, err
+			}
+			return driver.RowsAffected(1), nil
+		},
+	}
+}
+
+func (b *batchDriver) queries() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.runs...)
+}
+
+func newFakeBatch(t *testing.T, d *batchDriver, cfg *BatchConfig) Batch {
+	t.Helper()
+	b, err := connectFake(t, d.driver(), nil).NewBatch(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("new batch: %v", err)
+	}
+	return b
+}
+
+func scanQuery(t *testing.T, r Row) string {
+	t.Helper()
+	var q string
+	if err := r.Scan(context.Background(), &q); err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	return q
+}
+
+func TestBatchResults(t *testing.T) {
+	d := &batchDriver{}
+	b := newFakeBatch(t, d, nil)
+	ctx := context.Background()
+	b.QueueExec(ctx, "UPDATE a")
+	b.QueueQuery(ctx, "SELECT b")
+	b.QueueQueryRow(ctx, "SELECT c")
+
+	r, err := b.Do(ctx)
+	if err != nil {
+		t.Fatalf("do: %v", err)
+	}
+	res, err := r.Exec()
+	if err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+	if n, _ := res.RowsAffected(); n != 1 {
+		t.Fatalf("got %d rows affected, want 1", n)
+	}
+	rows, err := r.QueryResults()
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	if !rows.Next(ctx) {
+		t.Fatalf("next: %v", rows.Error())
+	}
+	var q string
+	if err = rows.Scan(&q); err != nil || q != "SELECT b" {
+		t.Fatalf("got %q, %v, want the rows of SELECT b", q, err)
+	}
+	_ = rows.Close(ctx)
+	if q = scanQuery(t, r.QueryRow()); q != "SELECT c" {
+		t.Fatalf("got %q, want the row of SELECT c", q)
+	}
+	if _, err = r.Exec(); !errors.Is(err, ErrBatchNoMoreResults) {
+		t.Fatalf("got %v, want %v", err, ErrBatchNoMoreResults)
+	}
+	if err = r.Close(ctx); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if _, err = b.Do(ctx); !errors.Is(err, ErrBatchClosed) {
+		t.Fatalf("got %v doing a closed batch, want %v", err, ErrBatchClosed)
+	}
+}
+
+func TestBatchResultsModeMismatch(t *testing.T) {
+	d := &batchDriver{}
+	b := newFakeBatch(t, d, nil)
+	ctx := context.Background()
+	b.QueueExec(ctx, "UPDATE a")
+	b.QueueExec(ctx, "UPDATE b")
+
+	r, err := b.Do(ctx)
+	if err != nil {
+		t.Fatalf("do: %v", err)
+	}
+	defer func() { _ = r.Close(ctx) }()
+	if _, err = r.QueryResults(); !errors.Is(err, ErrBatchOperationModeMismatch) {
+		t.Fatalf("got %v, want %v", err, ErrBatchOperationModeMismatch)
+	}
+	if _, err = r.Exec(); err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+	if got := d.queries(); !reflect.DeepEqual(got, []string{"UPDATE b"}) {
+		t.Fatalf("got %q run, want the mismatched operation skipped", got)
+	}
+}
+
+func TestBatchRemove(t *testing.T) {
+	d := &batchDriver{}
+	b := newFakeBatch(t, d, nil)
+	ctx := context.Background()
+	removeA := b.QueueExec(ctx, "UPDATE a")
+	b.QueueExec(ctx, "UPDATE b")
+	removeA()
+	// the id of the removed operation is not reused, so removing it again does not remove the one queued next
+	b.QueueExec(ctx, "UPDATE c")
+	removeA()
+
+	r, err := b.Do(ctx)
+	if err != nil {
+		t.Fatalf("do: %v", err)
+	}
+	defer func() { _ = r.Close(ctx) }()
+	for i := 0; i < 2; i++ {
+		if _, err = r.Exec(); err != nil {
+			t.Fatalf("exec: %v", err)
+		}
+	}
+	if _, err = r.Exec(); !errors.Is(err, ErrBatchNoMoreResults) {
+		t.Fatalf("got %v, want %v", err, ErrBatchNoMoreResults)
+	}
+	if got := d.queries(); !reflect.DeepEqual(got, []string{"UPDATE b", "UPDATE c"}) {
+		t.Fatalf("got %q run, want UPDATE b and UPDATE c", got)
+	}
+}
+
+func TestBatchFlush(t *testing.T) {
+	d := &batchDriver{}
+	b := newFakeBatch(t, d, nil)
+	ctx := context.Background()
+	b.QueueExec(ctx, "UPDATE a")
+	removeB := b.QueueExec(ctx, "UPDATE b")
+
+	r, err := b.Flush(ctx)
+	if err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err = r.Exec(); err != nil {
+			t.Fatalf("exec: %v", err)
+		}
+	}
+	if _, err = r.Exec(); !errors.Is(err, ErrBatchNoMoreResults) {
+		t.Fatalf("got %v, want the flushed results limited to the operations queued before, %v", err, ErrBatchNoMoreResults)
+	}
+	if err = r.Close(ctx); err != nil {
+		t.Fatalf("close flushed results: %v", err)
+	}
+
+	// the batch is still open, and the remover of a flushed operation does not remove the one queued next
+	b.QueueExec(ctx, "UPDATE c")
+	removeB()
+	b.QueueQueryRow(ctx, "SELECT d")
+
+	r, err = b.Do(ctx)
+	if err != nil {
+		t.Fatalf("do: %v", err)
+	}
+	if _, err = r.Exec(); err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+	if q := scanQuery(t, r.QueryRow()); q != "SELECT d" {
+		t.Fatalf("got %q, want the row of SELECT d", q)
+	}
+	if err = r.Close(ctx); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if got, want := d.queries(), []string{"UPDATE a", "UPDATE b", "UPDATE c", "SELECT d"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q run, want %q", got, want)
+	}
+	if err = b.Close(ctx); !errors.Is(err, ErrBatchClosed) {
+		t.Fatalf("got %v, want the batch closed along with the results of Do, %v", err, ErrBatchClosed)
+	}
+}
+
+func TestBatchFlushWhileProcessing(t *testing.T) {
+	d := &batchDriver{}
+	b := newFakeBatch(t, d, nil)
+	ctx := context.Background()
+	b.QueueExec(ctx, "UPDATE a")
+
+	r, err := b.Flush(ctx)
+	if err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	if _, err = b.Flush(ctx); !errors.Is(err, ErrBatchProcessing) {
+		t.Fatalf("got %v flushing before closing the results, want %v", err, ErrBatchProcessing)
+	}
+	if _, err = b.Do(ctx); !errors.Is(err, ErrBatchProcessing) {
+		t.Fatalf("got %v doing before closing the results, want %v", err, ErrBatchProcessing)
+	}
+	_ = r.Close(ctx)
+	if err = b.Close(ctx); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+}
+
+func TestBatchErrors(t *testing.T) {
+	errOne, errThree := errors.New("op 1 failure"), errors.New("op 3 failure")
+	queries := []string{"UPDATE op 0", "UPDATE op 1", "UPDATE op 2", "UPDATE op 3"}
+	tests := []struct {
+		name string
+		cfg  *BatchConfig
+		runs []string
+		errs []error
+	}{
+		{
+			name: "continue on error",
+			cfg:  &BatchConfig{},
+			runs: queries,
+			errs: []error{nil, errOne, nil, errThree},
+		},
+		{
+			name: "stop on error",
+			cfg:  &BatchConfig{StopOnError: true},
+			runs: queries[:2],
+			errs: []error{nil, errOne, ErrBatchAborted, ErrBatchAborted},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &batchDriver{fail: map[string]error{queries[1]: errOne, queries[3]: errThree}}
+			b := newFakeBatch(t, d, tt.cfg)
+			ctx := context.Background()
+			for _, q := range queries {
+				b.QueueExec(ctx, q)
+			}
+
+			r, err := b.Do(ctx)
+			if err != nil {
+				t.Fatalf("do: %v", err)
+			}
+			defer func() { _ = r.Close(ctx) }()
+			for i := range queries {
+				if _, err = r.Exec(); !errors.Is(err, tt.errs[i]) || (tt.errs[i] == nil) != (err == nil) {
+					t.Fatalf("got %v for operation %d, want %v", err, i, tt.errs[i])
+				}
+			}
+
+			errs := r.Errors()
+			if len(errs) != len(tt.errs) {
+				t.Fatalf("got %d errors %v, want %d", len(errs), errs, len(tt.errs))
+			}
+			for i, want := range tt.errs {
+				if !errors.Is(errs[i], want) || (want == nil) != (errs[i] == nil) {
+					t.Fatalf("got %v for operation %d, want %v", errs[i], i, want)
+				}
+			}
+			// the aborted operations wrap the failure aborting them, so only the executed ones are told apart
+			if errors.Is(errs[1], errThree) || (!tt.cfg.StopOnError && errors.Is(errs[3], errOne)) {
+				t.Fatalf("got the errors %v mixed up between the operations", errs)
+			}
+			if got := d.queries(); !reflect.DeepEqual(got, tt.runs) {
+				t.Fatalf("got %v run, want %v", got, tt.runs)
+			}
+		})
+	}
+}
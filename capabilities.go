@@ -0,0 +1,30 @@
+package alphasql
+
+import "database/sql/driver"
+
+// Capabilities describes which of the optional driver interfaces the underlying driver connection implements.
+type Capabilities struct {
+	Pinger             bool
+	QueryerContext     bool
+	ExecerContext      bool
+	ConnBeginTx        bool
+	ConnPrepareContext bool
+	NamedValueChecker  bool
+	SessionResetter    bool
+	Validator          bool
+}
+
+// Capabilities returns which of the optional driver interfaces the underlying driver connection implements,
+// so that the callers can adapt to them.
+func (c *Connection) Capabilities() Capabilities {
+	var cs Capabilities
+	_, cs.Pinger = c.c.(driver.Pinger)
+	_, cs.QueryerContext = c.c.(driver.QueryerContext)
+	_, cs.ExecerContext = c.c.(driver.ExecerContext)
+	_, cs.ConnBeginTx = c.c.(driver.ConnBeginTx)
+	_, cs.ConnPrepareContext = c.c.(driver.ConnPrepareContext)
+	_, cs.NamedValueChecker = c.c.(driver.NamedValueChecker)
+	_, cs.SessionResetter = c.c.(driver.SessionResetter)
+	_, cs.Validator = c.c.(driver.Validator)
+	return cs
+}
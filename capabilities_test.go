@@ -0,0 +1,46 @@
+package alphasql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+)
+
+func TestCapabilities(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		d    *fakedriver.Driver
+		want Capabilities
+	}{
+		{
+			name: "minimal",
+			d:    &fakedriver.Driver{Minimal: true},
+			want: Capabilities{},
+		},
+		{
+			name: "full",
+			d:    &fakedriver.Driver{},
+			want: Capabilities{
+				Pinger:             true,
+				QueryerContext:     true,
+				ExecerContext:      true,
+				ConnBeginTx:        true,
+				ConnPrepareContext: true,
+				SessionResetter:    true,
+				Validator:          true,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := connectFake(t, tc.d, nil)
+			if got := c.Capabilities(); got != tc.want {
+				t.Fatalf("got %+v, want %+v", got, tc.want)
+			}
+			// the connection still works without the optional interfaces, going through the prepared statements
+			if _, err := c.Exec(context.Background(), "DELETE FROM users"); err != nil {
+				t.Fatalf("exec: %v", err)
+			}
+		})
+	}
+}
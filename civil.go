@@ -0,0 +1,147 @@
+package alphasql
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+// civil layouts
+const (
+	dateLayout      = "2006-01-02"
+	timeOfDayLayout = "15:04:05.999999999"
+)
+
+// Date is a calendar date without any time or time zone, suitable for scanning DATE columns.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// TimeOfDay is a wall clock time without any date or time zone, suitable for scanning TIME columns.
+type TimeOfDay struct {
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+}
+
+// DateOf returns the [Date] in which t occurs in t's location.
+func DateOf(t time.Time) Date {
+	var d Date
+	d.Year, d.Month, d.Day = t.Date()
+	return d
+}
+
+// TimeOfDayOf returns the [TimeOfDay] at which t occurs in t's location.
+func TimeOfDayOf(t time.Time) TimeOfDay {
+	var tod TimeOfDay
+	tod.Hour, tod.Minute, tod.Second = t.Clock()
+	tod.Nanosecond = t.Nanosecond()
+	return tod
+}
+
+// ParseDate parses a string in the YYYY-MM-DD format and returns the [Date] value it represents.
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return Date{}, err
+	}
+	return DateOf(t), nil
+}
+
+// ParseTimeOfDay parses a string in the HH:MM:SS[.FFFFFFFFF] format and returns the [TimeOfDay] value it represents.
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	t, err := time.Parse(timeOfDayLayout, s)
+	if err != nil {
+		return TimeOfDay{}, err
+	}
+	return TimeOfDayOf(t), nil
+}
+
+// String returns the date in the YYYY-MM-DD format.
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// IsZero reports whether the date is the zero value.
+func (d Date) IsZero() bool {
+	return d.Year == 0 && d.Month == 0 && d.Day == 0
+}
+
+// In returns the time corresponding to the midnight of the date in the given location.
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// Scan implements the [Scanner] interface.
+func (d *Date) Scan(src any) error {
+	switch s := src.(type) {
+	case time.Time:
+		*d = DateOf(s)
+		return nil
+	case string:
+		return d.scanString(s)
+	case []byte:
+		return d.scanString(string(s))
+	case nil:
+		*d = Date{}
+		return nil
+	}
+	return fmt.Errorf("converting driver.Value type %T to a Date: %w", src, ErrRowsUnsupportedScan)
+}
+
+// Value implements the [driver.Valuer] interface.
+func (d Date) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+func (d *Date) scanString(s string) error {
+	v, err := ParseDate(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// String returns the time of day in the HH:MM:SS[.FFFFFFFFF] format.
+func (t TimeOfDay) String() string {
+	s := fmt.Sprintf("%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+	if t.Nanosecond == 0 {
+		return s
+	}
+	return s + time.Date(0, 1, 1, 0, 0, 0, t.Nanosecond, time.UTC).Format(".999999999")
+}
+
+// Scan implements the [Scanner] interface.
+func (t *TimeOfDay) Scan(src any) error {
+	switch s := src.(type) {
+	case time.Time:
+		*t = TimeOfDayOf(s)
+		return nil
+	case string:
+		return t.scanString(s)
+	case []byte:
+		return t.scanString(string(s))
+	case nil:
+		*t = TimeOfDay{}
+		return nil
+	}
+	return fmt.Errorf("converting driver.Value type %T to a TimeOfDay: %w", src, ErrRowsUnsupportedScan)
+}
+
+// Value implements the [driver.Valuer] interface.
+func (t TimeOfDay) Value() (driver.Value, error) {
+	return t.String(), nil
+}
+
+func (t *TimeOfDay) scanString(s string) error {
+	v, err := ParseTimeOfDay(s)
+	if err != nil {
+		return err
+	}
+	*t = v
+	return nil
+}
@@ -0,0 +1,59 @@
+package alphasql
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+)
+
+func TestScanDateColumn(t *testing.T) {
+	for name, src := range map[string]driver.Value{
+		"time":   time.Date(2024, time.February, 29, 0, 0, 0, 0, time.FixedZone("UTC-8", -8*60*60)),
+		"string": "2024-02-29",
+		"bytes":  []byte("2024-02-29"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := connectFake(t, rowsDriver(func() *fakedriver.Rows {
+				return fakedriver.NewRows([]string{"d"}, []driver.Value{src}).WithTypes("DATE")
+			}), nil)
+			var d Date
+			if err := c.QueryRow(context.Background(), "SELECT d").Scan(context.Background(), &d); err != nil {
+				t.Fatalf("scan: %v", err)
+			}
+			if want := (Date{Year: 2024, Month: time.February, Day: 29}); d != want {
+				t.Fatalf("got %v, want %v", d, want)
+			}
+		})
+	}
+}
+
+func TestScanTimeColumn(t *testing.T) {
+	c := connectFake(t, rowsDriver(func() *fakedriver.Rows {
+		return fakedriver.NewRows([]string{"t"}, []driver.Value{"13:45:30.5"}).WithTypes("TIME")
+	}), nil)
+	var tod TimeOfDay
+	if err := c.QueryRow(context.Background(), "SELECT t").Scan(context.Background(), &tod); err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	if want := (TimeOfDay{Hour: 13, Minute: 45, Second: 30, Nanosecond: 500000000}); tod != want {
+		t.Fatalf("got %v, want %v", tod, want)
+	}
+}
+
+func TestDateRoundTrip(t *testing.T) {
+	d := Date{Year: 1999, Month: time.December, Day: 31}
+	v, err := d.Value()
+	if err != nil {
+		t.Fatalf("value: %v", err)
+	}
+	var got Date
+	if err = got.Scan(v); err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	if got != d {
+		t.Fatalf("got %v, want %v", got, d)
+	}
+}
@@ -0,0 +1,15 @@
+package alphasql
+
+// RowCodec is used to decode a row of the result set directly into a destination, such as a protobuf message.
+// It is registered through [ConnectionConfig.RowCodec] and used by [Rows.DecodeInto].
+type RowCodec interface {
+	// DecodeRow decodes the values of the current row into dest. The values are in the same order as the columns,
+	// and are only valid until the next call to [Rows.Next].
+	DecodeRow(columns []Column, values []any, dest any) error
+}
+
+// ColumnDecoder decodes the value of a column read from the driver into a value of a user type, before it is
+// converted into the scan destination. It is registered through [ConnectionConfig.ColumnDecoders], keyed by the
+// database type name of the columns, for instance to decode the WKB bytes of a GEOMETRY column into a point.
+// NULL values are not passed to the decoder.
+type ColumnDecoder func(src any) (any, error)
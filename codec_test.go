@@ -0,0 +1,165 @@
+package alphasql
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+)
+
+type codecUser struct {
+	ID   int64
+	Name string
+}
+
+// mapCodec decodes the rows into a *codecUser by the names of the columns.
+type mapCodec struct{}
+
+func (mapCodec) DecodeRow(columns []Column, values []any, dest any) error {
+	u, ok := dest.(*codecUser)
+	if !ok {
+		return fmt.Errorf("unsupported destination %T", dest)
+	}
+	for i, c := range columns {
+		switch c.Name() {
+		case "id":
+			u.ID = values[i].(int64)
+		case "name":
+			u.Name = values[i].(string)
+		}
+	}
+	return nil
+}
+
+func usersCodecDriver() *fakedriver.Driver {
+	return rowsDriver(func() *fakedriver.Rows {
+		return fakedriver.NewRows([]string{"name", "id"}, []driver.Value{"a", int64(1)}, []driver.Value{"b", int64(2)})
+	})
+}
+
+func TestDecodeInto(t *testing.T) {
+	ctx := context.Background()
+	c := connectFake(t, usersCodecDriver(), &ConnectionConfig{RowCodec: mapCodec{}})
+	r, err := c.Query(ctx, "SELECT name, id FROM users")
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	defer func() { _ = r.Close(ctx) }()
+	var got []codecUser
+	for r.Next(ctx) {
+		var u codecUser
+		if err = r.DecodeInto(&u); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		got = append(got, u)
+	}
+	if len(got) != 2 || got[0] != (codecUser{1, "a"}) || got[1] != (codecUser{2, "b"}) {
+		t.Fatalf("got %v", got)
+	}
+}
+
+func TestDecodeIntoWithoutCodec(t *testing.T) {
+	ctx := context.Background()
+	r, err := connectFake(t, usersCodecDriver(), nil).Query(ctx, "SELECT name, id FROM users")
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	defer func() { _ = r.Close(ctx) }()
+	if !r.Next(ctx) {
+		t.Fatalf("next: %v", r.Error())
+	}
+	if err = r.DecodeInto(&codecUser{}); !errors.Is(err, ErrRowCodecNotConfigured) {
+		t.Fatalf("got %v, want %v", err, ErrRowCodecNotConfigured)
+	}
+}
+
+type point struct {
+	X, Y float64
+}
+
+var errNotPoint = errors.New("not a WKB point")
+
+// decodeWKBPoint decodes a point encoded in the little endian well-known binary format.
+func decodeWKBPoint(src any) (any, error) {
+	b, ok := src.([]byte)
+	if !ok || len(b) != 21 || b[0] != 1 || binary.LittleEndian.Uint32(b[1:]) != 1 {
+		return nil, errNotPoint
+	}
+	return point{
+		X: math.Float64frombits(binary.LittleEndian.Uint64(b[5:])),
+		Y: math.Float64frombits(binary.LittleEndian.Uint64(b[13:])),
+	}, nil
+}
+
+func encodeWKBPoint(p point) []byte {
+	b := make([]byte, 21)
+	b[0] = 1
+	binary.LittleEndian.PutUint32(b[1:], 1)
+	binary.LittleEndian.PutUint64(b[5:], math.Float64bits(p.X))
+	binary.LittleEndian.PutUint64(b[13:], math.Float64bits(p.Y))
+	return b
+}
+
+func queryGeometry(t *testing.T, location driver.Value) Rows {
+	t.Helper()
+	c := connectFake(t, rowsDriver(func() *fakedriver.Rows {
+		return fakedriver.NewRows([]string{"id", "location"}, []driver.Value{int64(1), location}).
+			WithTypes("BIGINT", "GEOMETRY")
+	}), &ConnectionConfig{ColumnDecoders: map[string]ColumnDecoder{"GEOMETRY": decodeWKBPoint}})
+	r, err := c.Query(context.Background(), "SELECT id, location FROM places")
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	t.Cleanup(func() { _ = r.Close(context.Background()) })
+	if !r.Next(context.Background()) {
+		t.Fatalf("next: %v", r.Error())
+	}
+	return r
+}
+
+func TestColumnDecoder(t *testing.T) {
+	want := point{X: 18.52, Y: 73.85}
+	r := queryGeometry(t, encodeWKBPoint(want))
+
+	var id int64
+	var got point
+	if err := r.Scan(&id, &got); err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	if id != 1 || got != want {
+		t.Fatalf("got %d at %+v, want 1 at %+v", id, got, want)
+	}
+	vs, err := r.Values()
+	if err != nil {
+		t.Fatalf("values: %v", err)
+	}
+	if vs[0] != int64(1) || vs[1] != want {
+		t.Fatalf("got values %v, want the location decoded", vs)
+	}
+}
+
+func TestColumnDecoderNull(t *testing.T) {
+	r := queryGeometry(t, nil)
+	var id int64
+	var got *point
+	if err := r.Scan(&id, &got); err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	if got != nil {
+		t.Fatalf("got %+v, want no point for a NULL location", got)
+	}
+}
+
+func TestColumnDecoderError(t *testing.T) {
+	r := queryGeometry(t, []byte("POINT(1 2)"))
+	var id int64
+	var got point
+	if err := r.Scan(&id, &got); !errors.Is(err, errNotPoint) {
+		t.Fatalf("got %v, want %v", err, errNotPoint)
+	}
+}
@@ -0,0 +1,30 @@
+package alphasql
+
+import "context"
+
+// CollectRows iterates the rows, calling fn for each of them to build a value of type T, and returns the values
+// collected. The rows are closed once it returns, even if fn fails, and the error encountered during the iteration,
+// if any, is returned. If the rows are empty, an empty slice is returned.
+func CollectRows[T any](ctx context.Context, r Rows, fn func(Rows) (T, error)) ([]T, error) {
+	defer func() { _ = r.Close(ctx) }()
+	result := make([]T, 0)
+	for r.Next(ctx) {
+		v, err := fn(r)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, v)
+	}
+	if err := r.Error(); err != nil {
+		return nil, err
+	}
+	return result, r.Close(ctx)
+}
+
+// RowToStruct scans the current row into a structure of type T, mapping the columns to the fields the same way
+// as [ScanStructure]. It can be passed to [CollectRows] to collect the rows into structures.
+func RowToStruct[T any](r Rows) (T, error) {
+	var v T
+	err := ScanStructure(r.Scan, r.Columns(), &v)
+	return v, err
+}
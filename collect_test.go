@@ -0,0 +1,84 @@
+package alphasql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+)
+
+// money is an amount scanned from its decimal text, implementing Scanner without being a plain structure.
+type money struct {
+	cents int64
+}
+
+func (m *money) Scan(src any) error {
+	s, ok := src.(string)
+	if !ok {
+		return fmt.Errorf("unsupported money %T", src)
+	}
+	var units, cents int64
+	if _, err := fmt.Sscanf(s, "%d.%02d", &units, &cents); err != nil {
+		return err
+	}
+	m.cents = units*100 + cents
+	return nil
+}
+
+func totalsDriver(totals ...driver.Value) *fakedriver.Driver {
+	return rowsDriver(func() *fakedriver.Rows {
+		rows := make([][]driver.Value, len(totals))
+		for i, total := range totals {
+			rows[i] = []driver.Value{total}
+		}
+		return fakedriver.NewRows([]string{"total"}, rows...)
+	})
+}
+
+func TestCollectRowsIntoScanner(t *testing.T) {
+	c := connectFake(t, totalsDriver("12.34", "0.05", "100.00"), nil)
+	ctx := context.Background()
+	r, err := c.Query(ctx, "SELECT SUM(amount) AS total FROM payments GROUP BY user_id")
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	totals, err := CollectRows(ctx, r, RowToStruct[money])
+	if err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	want := []money{{1234}, {5}, {10000}}
+	if len(totals) != len(want) {
+		t.Fatalf("got %v, want %v", totals, want)
+	}
+	for i := range want {
+		if totals[i] != want[i] {
+			t.Fatalf("got %v, want %v", totals, want)
+		}
+	}
+}
+
+func TestQueryStructsIntoScanner(t *testing.T) {
+	c := connectFake(t, totalsDriver("1.50", "2.25"), nil)
+	var totals []money
+	if err := c.QueryStructs(context.Background(), &totals, "SELECT SUM(amount) AS total FROM payments GROUP BY user_id"); err != nil {
+		t.Fatalf("query structs: %v", err)
+	}
+	if len(totals) != 2 || totals[0].cents != 150 || totals[1].cents != 225 {
+		t.Fatalf("got %v, want [150 225] cents", totals)
+	}
+}
+
+func TestCollectRowsIntoScannerError(t *testing.T) {
+	c := connectFake(t, totalsDriver("1.50", int64(2)), nil)
+	ctx := context.Background()
+	r, err := c.Query(ctx, "SELECT SUM(amount) AS total FROM payments GROUP BY user_id")
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	if _, err = CollectRows(ctx, r, RowToStruct[money]); !errors.Is(err, ErrRowsUnexpectedScan) {
+		t.Fatalf("got %v, want %v", err, ErrRowsUnexpectedScan)
+	}
+}
@@ -40,8 +40,9 @@ func (c *Column) PrecisionScale() (precision, scale int64, ok bool) {
 }
 
 // ScanType returns a Go type suitable for scanning into using [Rows.Scan].
-// If a driver does not support this property ScanType will return
-// the type of empty interface.
+// If a driver does not support this property ScanType will return the type
+// configured for the database type name in [ConnectionConfig.ColumnScanTypeByName],
+// or the type of empty interface otherwise.
 func (c *Column) ScanType() reflect.Type {
 	return c.scanType
 }
@@ -62,19 +63,21 @@ func (c *Column) DatabaseTypeName() string {
 	return c.databaseType
 }
 
-func getColumnsFromDriverColumns(r driver.Rows) []Column {
+func getColumnsFromDriverColumns(r driver.Rows, scanTypes map[string]reflect.Type) []Column {
 	names := r.Columns()
 	columns := make([]Column, len(names))
 	for i, n := range names {
 		c := Column{name: n}
+		if dt, ok := r.(driver.RowsColumnTypeDatabaseTypeName); ok {
+			c.databaseType = dt.ColumnTypeDatabaseTypeName(i)
+		}
 		if st, ok := r.(driver.RowsColumnTypeScanType); ok {
 			c.scanType = st.ColumnTypeScanType(i)
+		} else if t, ok := scanTypes[c.databaseType]; ok && t != nil {
+			c.scanType = t
 		} else {
 			c.scanType = reflect.TypeFor[any]()
 		}
-		if dt, ok := r.(driver.RowsColumnTypeDatabaseTypeName); ok {
-			c.databaseType = dt.ColumnTypeDatabaseTypeName(i)
-		}
 		if cl, ok := r.(driver.RowsColumnTypeLength); ok {
 			c.length, c.hasLength = cl.ColumnTypeLength(i)
 		}
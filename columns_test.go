@@ -0,0 +1,31 @@
+package alphasql
+
+import (
+	"context"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+)
+
+func TestColumnScanTypeByName(t *testing.T) {
+	c := connectFake(t, rowsDriver(func() *fakedriver.Rows {
+		return fakedriver.NewRows([]string{"id", "name", "other"}, []driver.Value{int64(1), "a", 1.5}).
+			WithTypes("BIGINT", "TEXT", "FLOAT")
+	}), &ConnectionConfig{ColumnScanTypeByName: map[string]reflect.Type{
+		"BIGINT": reflect.TypeFor[int64](),
+		"TEXT":   reflect.TypeFor[string](),
+	}})
+	r, err := c.Query(context.Background(), "SELECT id, name, other")
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	defer func() { _ = r.Close(context.Background()) }()
+	columns := r.Columns()
+	for i, want := range []reflect.Type{reflect.TypeFor[int64](), reflect.TypeFor[string](), reflect.TypeFor[any]()} {
+		if got := columns[i].ScanType(); got != want {
+			t.Errorf("column %s: got scan type %s, want %s", columns[i].Name(), got, want)
+		}
+	}
+}
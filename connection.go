@@ -3,17 +3,84 @@ package alphasql
 import (
 	"context"
 	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
 )
 
 // ConnectionConfig is the set of parameters needed to initialise the connection.
 type ConnectionConfig struct {
 	DriverName string
 	URL        string
+
+	// RowPrefetch is the number of rows read ahead in the background while iterating the result of a query.
+	// It helps large result sets over high latency links when the driver does not buffer the rows itself.
+	// Zero means the rows are read from the driver one at a time as [Rows.Next] is called.
+	RowPrefetch int
+
+	// ColumnScanTypeByName provides the scan types of the columns keyed by their database type name, used as a
+	// fallback when the driver does not report the scan type of a column itself.
+	ColumnScanTypeByName map[string]reflect.Type
+
+	// RedactQuery is used to redact the SQL of a query before it is included in a [QueryError].
+	// If it is nil, the SQL is included as is.
+	RedactQuery func(query string) string
+
+	// RowCodec is used by [Rows.DecodeInto] to decode the rows directly into a destination.
+	RowCodec RowCodec
+
+	// ColumnDecoders are used to decode the values of the columns keyed by their database type name, before
+	// they are converted into the scan destinations.
+	ColumnDecoders map[string]ColumnDecoder
+
+	// ErrorMap is the list of mappings applied in order to the errors of the queries and executions, as well as
+	// the errors beginning, committing and rolling back the transactions and closing the statements, wrapping
+	// the driver errors matching a mapping with its sentinel error, like [ErrUniqueViolation]. Each mapping
+	// must have both its Match and Err set.
+	ErrorMap []ErrorMapping
+
+	// MaxBufferedBytes is the approximate maximum number of bytes of the rows buffered in memory by the
+	// operations buffering them, like [Rows.ScanAll] or the prefetching of the rows, beyond which they are
+	// aborted with [ErrResultTooLarge]. Zero means no limit.
+	MaxBufferedBytes int64
+
+	// InsertIDQuery is the query used by [Connection.Insert] to fetch the id generated for the inserted row
+	// when the driver does not report it in the [Result], for instance `SELECT lastval()`.
+	InsertIDQuery string
+
+	// InsertReturnsID makes [Connection.Insert] scan the id from the row returned by the insert query itself,
+	// for the queries with a RETURNING clause.
+	InsertReturnsID bool
+
+	// DrainOnClose makes closing the [Rows] before they are exhausted read the remaining rows, including the ones of
+	// the further result sets, before closing them, for the drivers leaving the connection in a bad state otherwise.
+	// The draining stops once the context passed to [Rows.Close] is done, Close returning [ErrBadConnection] so the
+	// connection is discarded, as the pools do.
+	DrainOnClose bool
+
+	// NullTimeAsZero makes scanning a NULL column into a *time.Time set it to the zero time, instead of failing.
+	NullTimeAsZero bool
+
+	// MaxQueryLength is the maximum length of a query, beyond which the query is rejected with [ErrQueryTooLong]
+	// before being sent to the database. Zero means no limit.
+	MaxQueryLength int
 }
 
 // Connection is used as the connection created.
 type Connection struct {
-	c driver.Conn
+	c   driver.Conn
+	db  *DB
+	cfg *ConnectionConfig
+
+	// closed makes sure the connection is only accounted as closed in the DB once.
+	closed atomic.Bool
+
+	namedStatementsMu sync.Mutex
+	namedStatements   map[string]*namedStatement
+
+	// inTX is set while a transaction begun on the connection has not been committed or rolled back.
+	inTX atomic.Bool
 }
 
 // ValidateAndDefault is used to validate and set the defaults for the mandatory parameters not passed.
@@ -24,24 +91,59 @@ func (c *ConnectionConfig) ValidateAndDefault() error {
 	if c.URL == "" {
 		return ErrMissingURL
 	}
+	for i, m := range c.ErrorMap {
+		if m.Match == nil || m.Err == nil {
+			return fmt.Errorf("%w: mapping %d", ErrInvalidErrorMapping, i)
+		}
+	}
 	return nil
 }
 
-// Copy is used to copy the connection config.
+// Copy is used to copy the connection config. The maps and the error mappings are copied as well, so modifying
+// the copy does not affect the config copied.
 func (c *ConnectionConfig) Copy() *ConnectionConfig {
-	return &ConnectionConfig{DriverName: c.DriverName, URL: c.URL}
+	cc := *c
+	if c.ColumnScanTypeByName != nil {
+		cc.ColumnScanTypeByName = make(map[string]reflect.Type, len(c.ColumnScanTypeByName))
+		for k, v := range c.ColumnScanTypeByName {
+			cc.ColumnScanTypeByName[k] = v
+		}
+	}
+	if c.ColumnDecoders != nil {
+		cc.ColumnDecoders = make(map[string]ColumnDecoder, len(c.ColumnDecoders))
+		for k, v := range c.ColumnDecoders {
+			cc.ColumnDecoders[k] = v
+		}
+	}
+	if c.ErrorMap != nil {
+		cc.ErrorMap = append([]ErrorMapping(nil), c.ErrorMap...)
+	}
+	return &cc
 }
 
 // Connect is used to create a new connection.
 func (db *DB) Connect(ctx context.Context) (*Connection, error) {
+	return db.ConnectWithConfig(ctx, db.cfg)
+}
+
+// ConnectWithConfig is used to create a new connection using the config provided, which is typically
+// a modified [ConnectionConfig.Copy] of the config the [DB] was opened with, for instance carrying rotated credentials
+// in the URL. The driver the [DB] was opened with is always used.
+func (db *DB) ConnectWithConfig(ctx context.Context, cfg *ConnectionConfig) (*Connection, error) {
 	if db.closed.Load() {
 		return nil, ErrDBClosed
 	}
-	c, err := db.c.Connect(ctx)
+	dc, err := db.connector(cfg.URL)
+	if err != nil {
+		return nil, err
+	}
+	c, err := dc.Connect(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return &Connection{c: c}, nil
+	db.openConnections.Add(1)
+	db.totalConnections.Add(1)
+	return &Connection{c: c, db: db, cfg: cfg}, nil
 }
 
 // Connection is used to get the underlying driver connection.
@@ -61,5 +163,9 @@ func (c *Connection) Connection() driver.Conn {
 // Drivers must ensure all network calls made by Close
 // do not block indefinitely (e.g. apply a timeout).
 func (c *Connection) Close() error {
+	if c.closed.CompareAndSwap(false, true) {
+		c.db.openConnections.Add(-1)
+	}
+	c.closeNamedStatements()
 	return c.c.Close()
 }
@@ -3,14 +3,27 @@ package alphasql
 import (
 	"context"
 	"database/sql/driver"
+	"errors"
 	"fmt"
 	"io"
+	"sync"
 	"sync/atomic"
 )
 
 // DB is the instance that will be used to start new connections.
 type DB struct {
-	c driver.Connector
+	// openConnections and totalConnections count the connections currently open and ever opened by the DB.
+	openConnections  atomic.Int64
+	totalConnections atomic.Int64
+
+	c   driver.Connector
+	d   driver.DriverContext
+	cfg *ConnectionConfig
+
+	// connectorsMu guards connectors, which holds the connectors opened for URLs other than the one
+	// the DB was opened with, for instance after the credentials are rotated.
+	connectorsMu sync.Mutex
+	connectors   map[string]driver.Connector
 
 	closed               atomic.Bool
 	baseAcquireCtx       context.Context
@@ -30,7 +43,14 @@ func Open(ctx context.Context, cfg *ConnectionConfig) (*DB, error) {
 		return nil, err
 	}
 	baseAcquireCtx, cancelBaseAcquireCtx := context.WithCancel(ctx)
-	return &DB{c: c, baseAcquireCtx: baseAcquireCtx, cancelBaseAcquireCtx: cancelBaseAcquireCtx}, nil
+	return &DB{
+		c:                    c,
+		d:                    d,
+		cfg:                  cfg.Copy(),
+		connectors:           make(map[string]driver.Connector),
+		baseAcquireCtx:       baseAcquireCtx,
+		cancelBaseAcquireCtx: cancelBaseAcquireCtx,
+	}, nil
 }
 
 // Close closes the database and prevents new queries from starting.
@@ -42,10 +62,60 @@ func Open(ctx context.Context, cfg *ConnectionConfig) (*DB, error) {
 func (db *DB) Close() error {
 	if db.closed.CompareAndSwap(false, true) {
 		defer db.cancelBaseAcquireCtx()
+		var errs []error
 		if c, ok := db.c.(io.Closer); ok {
-			return c.Close()
+			errs = append(errs, c.Close())
+		}
+		db.connectorsMu.Lock()
+		defer db.connectorsMu.Unlock()
+		for _, c := range db.connectors {
+			if cc, ok := c.(io.Closer); ok {
+				errs = append(errs, cc.Close())
+			}
 		}
-		return nil
+		return errors.Join(errs...)
 	}
 	return ErrDBClosed
 }
+
+// connector returns the connector to be used for the URL provided.
+func (db *DB) connector(url string) (driver.Connector, error) {
+	if url == db.cfg.URL {
+		return db.c, nil
+	}
+	db.connectorsMu.Lock()
+	defer db.connectorsMu.Unlock()
+	if c, ok := db.connectors[url]; ok {
+		return c, nil
+	}
+	c, err := db.d.OpenConnector(url)
+	if err != nil {
+		return nil, err
+	}
+	db.connectors[url] = c
+	return c, nil
+}
+
+// DBStats is a snapshot of the statistics of the connections opened by a [DB].
+type DBStats struct {
+	openConnections  int64
+	totalConnections int64
+}
+
+// Stats returns a snapshot of the statistics of the connections opened by the DB.
+func (db *DB) Stats() *DBStats {
+	return &DBStats{
+		openConnections:  db.openConnections.Load(),
+		totalConnections: db.totalConnections.Load(),
+	}
+}
+
+// OpenConnections returns the number of connections opened by the DB and not closed yet.
+func (s *DBStats) OpenConnections() int64 {
+	return s.openConnections
+}
+
+// TotalConnections returns the cumulative count of connections opened by the DB.
+func (s *DBStats) TotalConnections() int64 {
+	return s.totalConnections
+}
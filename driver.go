@@ -2,6 +2,7 @@ package alphasql
 
 import (
 	"database/sql/driver"
+	"sort"
 	"sync"
 )
 
@@ -12,8 +13,35 @@ var (
 )
 
 // RegisterDriver is used to register a driver.
+// If RegisterDriver is called twice with the same name or if the driver is nil, it panics.
 func RegisterDriver(name string, d driver.DriverContext) {
 	driversMu.Lock()
 	defer driversMu.Unlock()
+	if d == nil {
+		panic("alphasql: register driver is nil")
+	}
+	if _, dup := drivers[name]; dup {
+		panic("alphasql: register called twice for driver " + name)
+	}
 	drivers[name] = d
 }
+
+// DeregisterDriver is used to remove a registered driver. It is a noop if no driver is registered with the name.
+// The DB instances already opened with the driver are not affected.
+func DeregisterDriver(name string) {
+	driversMu.Lock()
+	defer driversMu.Unlock()
+	delete(drivers, name)
+}
+
+// Drivers returns a sorted list of the names of the registered drivers.
+func Drivers() []string {
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+	list := make([]string, 0, len(drivers))
+	for name := range drivers {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+	return list
+}
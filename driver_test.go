@@ -0,0 +1,87 @@
+package alphasql
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+)
+
+func hasDriver(name string) bool {
+	list := Drivers()
+	i := sort.SearchStrings(list, name)
+	return i < len(list) && list[i] == name
+}
+
+func TestRegisterDriver(t *testing.T) {
+	a, b := t.Name()+"#a", t.Name()+"#b"
+	RegisterDriver(b, &fakedriver.Driver{})
+	RegisterDriver(a, &fakedriver.Driver{})
+	t.Cleanup(func() {
+		DeregisterDriver(a)
+		DeregisterDriver(b)
+	})
+
+	list := Drivers()
+	if !sort.StringsAreSorted(list) {
+		t.Fatalf("got drivers %q, want them sorted", list)
+	}
+	if !hasDriver(a) || !hasDriver(b) {
+		t.Fatalf("got drivers %q, want both %q and %q", list, a, b)
+	}
+
+	DeregisterDriver(a)
+	if hasDriver(a) || !hasDriver(b) {
+		t.Fatalf("got drivers %q, want only %q deregistered", Drivers(), a)
+	}
+	if _, err := Open(context.Background(), &ConnectionConfig{DriverName: a, URL: "fake"}); err == nil {
+		t.Fatal("got no error opening a deregistered driver")
+	}
+	// deregistering an unknown driver is a noop, and the name may be registered again
+	DeregisterDriver(a)
+	RegisterDriver(a, &fakedriver.Driver{})
+	if !hasDriver(a) {
+		t.Fatalf("got drivers %q, want %q registered again", Drivers(), a)
+	}
+}
+
+func TestDeregisterDriverKeepsOpenedDB(t *testing.T) {
+	db := openFakeDB(t, &fakedriver.Driver{}, nil)
+	DeregisterDriver(db.cfg.DriverName)
+	c, err := db.Connect(context.Background())
+	if err != nil {
+		t.Fatalf("connect after deregistering: %v", err)
+	}
+	_ = c.Close()
+}
+
+func TestRegisterDriverPanics(t *testing.T) {
+	name := t.Name()
+	RegisterDriver(name, &fakedriver.Driver{})
+	t.Cleanup(func() { DeregisterDriver(name) })
+
+	for _, tc := range []struct {
+		name string
+		d    *fakedriver.Driver
+		want string
+	}{
+		{"duplicate", &fakedriver.Driver{}, "register called twice"},
+		{"nil", nil, "register driver is nil"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				r := recover()
+				if s, ok := r.(string); !ok || !strings.Contains(s, tc.want) {
+					t.Fatalf("got panic %v, want it to mention %q", r, tc.want)
+				}
+			}()
+			if tc.d == nil {
+				RegisterDriver(name, nil)
+			} else {
+				RegisterDriver(name, tc.d)
+			}
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package alphasql
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"fmt"
+)
 
 // errors
 var (
@@ -12,6 +16,9 @@ var (
 	ErrPoolSpaceNotAvailable          = errors.New("no space available to create new connections")
 	ErrORMClosed                      = errors.New("orm is closed")
 	ErrPoolClosed                     = errors.New("closed pool")
+	ErrAcquireTimeout                 = errors.New("timed out acquiring a connection")
+	ErrOnAcquireFailed                = errors.New("acquire hook failed")
+	ErrMinConnectionsNotMet           = errors.New("could not establish the minimum connections")
 	ErrRowsClosed                     = errors.New("rows are closed")
 	ErrNoRows                         = errors.New("no rows in result set")
 	ErrNoRowsAffected                 = errors.New("no rows affected")
@@ -22,12 +29,109 @@ var (
 	ErrTXClosed                       = errors.New("transaction has already been committed or rolled back")
 	ErrTXOptionsInvalidIsolationLevel = errors.New("invalid transaction isolation level")
 	ErrTXOptionsInvalidAccessMode     = errors.New("invalid transaction access mode")
+	ErrTransactionInProgress          = errors.New("transaction already in progress on the connection")
+	ErrInvalidSavepointName           = errors.New("invalid savepoint name")
 	ErrNamedArgNoLetterBegin          = errors.New("name does not begin with a letter")
+	ErrNamedArgNotSupported           = errors.New("driver does not support the use of named parameters")
 	ErrConvertingArgumentToNamedArg   = errors.New("unable to convert argument to named arg")
 	ErrNilPointer                     = errors.New("destination pointer is nil")
 	ErrNotAPointer                    = errors.New("destination is not a pointer")
+	ErrNotAStructure                  = errors.New("not a structure")
 	ErrBadConnection                  = errors.New("bad connection")
 	ErrScanToStructureNotEnabled      = errors.New("scanning to a structure not enabled")
 	ErrBatchProcessing                = errors.New("batch is processing")
 	ErrBatchClosed                    = errors.New("batch is closed")
+	ErrBatchOperationModeMismatch     = errors.New("batch operation mode mismatch")
+	ErrBatchNoMoreResults             = errors.New("no more results in the batch")
+	ErrBatchAborted                   = errors.New("batch aborted after a failed operation")
+	ErrStatementClosed                = errors.New("statement is closed")
+	ErrRowCodecNotConfigured          = errors.New("no row codec configured")
+	ErrInvalidIdleSelectionPolicy     = errors.New("invalid idle selection policy")
+	ErrInvalidMaxConnections          = errors.New("invalid maximum connections")
+	ErrInvalidMinConnections          = errors.New("minimum connections exceed the maximum connections")
+	ErrMinConnectionsWithoutWarmup    = errors.New("required minimum connections need a synchronous warmup")
+	ErrQueryTooLong                   = errors.New("query exceeds the maximum length")
+	ErrBatchSaveNotSupported          = errors.New("entity does not support batch save")
+	ErrBatchSaveValuesMismatch        = errors.New("entity values do not match the batch save columns")
+	ErrBatchSaveNoConflictColumns     = errors.New("batch save needs at least one conflict column")
+	ErrOptimisticLockConflict         = errors.New("entity was modified concurrently")
+	ErrResultTooLarge                 = errors.New("result exceeds the maximum buffered bytes")
+	ErrResultUnavailable              = errors.New("result unavailable from the driver")
+	ErrUniqueViolation                = errors.New("unique violation")
+	ErrForeignKeyViolation            = errors.New("foreign key violation")
+	ErrDeadlock                       = errors.New("deadlock detected")
+	ErrUnknownDatabase                = errors.New("unknown database")
+	ErrInvalidErrorMapping            = errors.New("invalid error mapping")
 )
+
+// ErrorMapping maps the driver errors reporting a logical condition, like a unique violation, to the
+// sentinel error of the condition, for instance [ErrUniqueViolation], so it can be checked with [errors.Is]
+// regardless of the driver. It is registered through [ConnectionConfig.ErrorMap].
+type ErrorMapping struct {
+	// Match reports whether the driver error reports the condition.
+	Match func(error) bool
+	// Err is the sentinel error of the condition.
+	Err error
+}
+
+// QueryError is the error returned when a query fails, carrying the query and the operation label
+// it was run with, so the failures can be traced back to their origin.
+type QueryError struct {
+	// Query is the SQL of the query, redacted with [ConnectionConfig.RedactQuery] when configured.
+	Query string
+	// Label is the operation label set on the context with [WithQueryLabel], if any.
+	Label string
+	// Err is the underlying error.
+	Err error
+}
+
+type queryLabelKey struct{}
+
+// WithQueryLabel returns a copy of the context carrying the operation label to be included in
+// the [QueryError] of any query failing with it.
+func WithQueryLabel(ctx context.Context, label string) context.Context {
+	return context.WithValue(ctx, queryLabelKey{}, label)
+}
+
+// QueryLabel returns the operation label set on the context with [WithQueryLabel], if any.
+func QueryLabel(ctx context.Context) string {
+	label, _ := ctx.Value(queryLabelKey{}).(string)
+	return label
+}
+
+func (e *QueryError) Error() string {
+	if e.Label == "" {
+		return fmt.Sprintf("query %q: %v", e.Query, e.Err)
+	}
+	return fmt.Sprintf("%s: query %q: %v", e.Label, e.Query, e.Err)
+}
+
+func (e *QueryError) Unwrap() error {
+	return e.Err
+}
+
+func newQueryError(ctx context.Context, cfg *ConnectionConfig, query string, err error) error {
+	if cfg.RedactQuery != nil {
+		query = cfg.RedactQuery(query)
+	}
+	return &QueryError{Query: query, Label: QueryLabel(ctx), Err: mapError(cfg.ErrorMap, err)}
+}
+
+// mapError wraps err with the sentinel error of the first mapping matching it, keeping err in the chain.
+func mapError(mappings []ErrorMapping, err error) error {
+	if err == nil {
+		return nil
+	}
+	for _, m := range mappings {
+		if m.Match == nil || m.Err == nil {
+			continue
+		}
+		if errors.Is(err, m.Err) {
+			return err
+		}
+		if m.Match(err) {
+			return fmt.Errorf("%w: %w", m.Err, err)
+		}
+	}
+	return err
+}
@@ -0,0 +1,206 @@
+package alphasql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+)
+
+var errDriver = errors.New("driver failure")
+
+// failingDriver returns a fake driver failing every query and execution with err.
+func failingDriver(err error) *fakedriver.Driver {
+	return &fakedriver.Driver{
+		Query: func(_ context.Context, _ *fakedriver.Conn, _ string, _ []driver.NamedValue) (driver.Rows, error) {
+			return nil, err
+		},
+		Exec: func(_ context.Context, _ *fakedriver.Conn, _ string, _ []driver.NamedValue) (driver.Result, error) {
+			return nil, err
+		},
+	}
+}
+
+func TestQueryErrorIncludesQueryAndLabel(t *testing.T) {
+	c := connectFake(t, failingDriver(errDriver), nil)
+	ctx := WithQueryLabel(context.Background(), "load-user")
+	_, err := c.Query(ctx, "SELECT name FROM users WHERE id = $1", 1)
+	var qe *QueryError
+	if !errors.As(err, &qe) {
+		t.Fatalf("got %v, want a *QueryError", err)
+	}
+	if qe.Query != "SELECT name FROM users WHERE id = $1" || qe.Label != "load-user" {
+		t.Fatalf("got query %q and label %q", qe.Query, qe.Label)
+	}
+	for _, want := range []string{"load-user", "SELECT name FROM users WHERE id = $1", errDriver.Error()} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("got message %q, want it to include %q", err.Error(), want)
+		}
+	}
+	if !errors.Is(err, errDriver) {
+		t.Fatalf("got %v, want it to wrap %v", err, errDriver)
+	}
+}
+
+func TestQueryErrorRedactsQuery(t *testing.T) {
+	c := connectFake(t, failingDriver(errDriver), &ConnectionConfig{
+		RedactQuery: func(query string) string { return strings.ReplaceAll(query, "secret", "***") },
+	})
+	_, err := c.Exec(context.Background(), "UPDATE users SET password = 'secret'")
+	if err == nil {
+		t.Fatal("got no error")
+	}
+	if strings.Contains(err.Error(), "secret") || !strings.Contains(err.Error(), "password = '***'") {
+		t.Fatalf("got message %q, want the query redacted", err.Error())
+	}
+	if !strings.HasPrefix(err.Error(), "query ") {
+		t.Fatalf("got message %q, want no label when none is set", err.Error())
+	}
+}
+
+// codeError is a driver error reporting a condition by its code, like the SQLSTATE of PostgreSQL.
+type codeError struct {
+	code string
+}
+
+func (e *codeError) Error() string {
+	return "driver error " + e.code
+}
+
+func matchCode(code string) func(error) bool {
+	return func(err error) bool {
+		var ce *codeError
+		return errors.As(err, &ce) && ce.code == code
+	}
+}
+
+var codeErrorMap = []ErrorMapping{
+	{Match: matchCode("23505"), Err: ErrUniqueViolation},
+	{Match: matchCode("23503"), Err: ErrForeignKeyViolation},
+	{Match: matchCode("40P01"), Err: ErrDeadlock},
+}
+
+func TestErrorMap(t *testing.T) {
+	for _, tc := range []struct {
+		code string
+		want error
+	}{
+		{"23505", ErrUniqueViolation},
+		{"23503", ErrForeignKeyViolation},
+		{"40P01", ErrDeadlock},
+	} {
+		t.Run(tc.code, func(t *testing.T) {
+			driverErr := &codeError{code: tc.code}
+			c := connectFake(t, failingDriver(driverErr), &ConnectionConfig{ErrorMap: codeErrorMap})
+			ctx := context.Background()
+
+			_, err := c.Exec(ctx, "INSERT INTO users (id) VALUES (1)")
+			if !errors.Is(err, tc.want) || !errors.Is(err, driverErr) {
+				t.Fatalf("got %v executing, want both %v and the driver error", err, tc.want)
+			}
+			_, err = c.Query(ctx, "SELECT id FROM users")
+			if !errors.Is(err, tc.want) || !errors.Is(err, driverErr) {
+				t.Fatalf("got %v querying, want both %v and the driver error", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestErrorMapUnmatched(t *testing.T) {
+	c := connectFake(t, failingDriver(errDriver), &ConnectionConfig{ErrorMap: codeErrorMap})
+	_, err := c.Exec(context.Background(), "DELETE FROM users")
+	if !errors.Is(err, errDriver) {
+		t.Fatalf("got %v, want %v", err, errDriver)
+	}
+	for _, m := range codeErrorMap {
+		if errors.Is(err, m.Err) {
+			t.Fatalf("got %v, want it not mapped to %v", err, m.Err)
+		}
+	}
+}
+
+func TestErrorMapTransaction(t *testing.T) {
+	deadlock := &codeError{code: "40P01"}
+	for _, tc := range []struct {
+		name string
+		d    *fakedriver.Driver
+		run  func(*Connection) error
+	}{
+		{
+			name: "begin",
+			d:    &fakedriver.Driver{Begin: func(*fakedriver.Conn) error { return deadlock }},
+			run: func(c *Connection) error {
+				_, err := c.BeginTX(context.Background(), nil)
+				return err
+			},
+		},
+		{
+			name: "commit",
+			d:    &fakedriver.Driver{Commit: func(*fakedriver.Conn) error { return deadlock }},
+			run: func(c *Connection) error {
+				tx, err := c.BeginTX(context.Background(), nil)
+				if err != nil {
+					return err
+				}
+				return tx.Commit(context.Background())
+			},
+		},
+		{
+			name: "rollback",
+			d:    &fakedriver.Driver{Rollback: func(*fakedriver.Conn) error { return deadlock }},
+			run: func(c *Connection) error {
+				tx, err := c.BeginTX(context.Background(), nil)
+				if err != nil {
+					return err
+				}
+				return tx.Rollback(context.Background())
+			},
+		},
+		{
+			name: "statement close",
+			d:    &fakedriver.Driver{CloseStatement: func(string) error { return deadlock }},
+			run: func(c *Connection) error {
+				s, err := c.Prepare(context.Background(), "SELECT 1")
+				if err != nil {
+					return err
+				}
+				return s.Close(context.Background())
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := connectFake(t, tc.d, &ConnectionConfig{ErrorMap: codeErrorMap})
+			if err := tc.run(c); !errors.Is(err, ErrDeadlock) || !errors.Is(err, deadlock) {
+				t.Fatalf("got %v, want both %v and the driver error", err, ErrDeadlock)
+			}
+		})
+	}
+}
+
+func TestErrorMapValidation(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		m    ErrorMapping
+	}{
+		{"missing match", ErrorMapping{Err: ErrUniqueViolation}},
+		{"missing error", ErrorMapping{Match: matchCode("23505")}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &ConnectionConfig{DriverName: "fake", URL: "fake", ErrorMap: append(codeErrorMap[:1:1], tc.m)}
+			err := cfg.ValidateAndDefault()
+			if !errors.Is(err, ErrInvalidErrorMapping) {
+				t.Fatalf("got %v, want %v", err, ErrInvalidErrorMapping)
+			}
+			if !strings.Contains(err.Error(), "mapping 1") {
+				t.Fatalf("got %q, want it to name the invalid mapping", err.Error())
+			}
+		})
+	}
+	cfg := &ConnectionConfig{DriverName: "fake", URL: "fake", ErrorMap: codeErrorMap}
+	if err := cfg.ValidateAndDefault(); err != nil {
+		t.Fatalf("got %v, want the complete mappings valid", err)
+	}
+}
@@ -0,0 +1,67 @@
+package alphasql
+
+import (
+	"context"
+	"database/sql/driver"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+)
+
+// fakeDrivers numbers the fake drivers registered, so a test may register several of them.
+var fakeDrivers atomic.Int64
+
+// openFakeDB opens a DB over the fake driver provided, registered under a name derived from the test.
+func openFakeDB(t *testing.T, d *fakedriver.Driver, cfg *ConnectionConfig) *DB {
+	t.Helper()
+	if cfg == nil {
+		cfg = &ConnectionConfig{}
+	}
+	cfg.DriverName = t.Name() + "#" + strconv.FormatInt(fakeDrivers.Add(1), 10)
+	if cfg.URL == "" {
+		cfg.URL = "fake"
+	}
+	RegisterDriver(cfg.DriverName, d)
+	t.Cleanup(func() { DeregisterDriver(cfg.DriverName) })
+	db, err := Open(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+// connectFake opens a Connection over the fake driver provided.
+func connectFake(t *testing.T, d *fakedriver.Driver, cfg *ConnectionConfig) *Connection {
+	t.Helper()
+	c, err := openFakeDB(t, d, cfg).Connect(context.Background())
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+// rowsDriver returns a fake driver answering every query with the rows built by rows.
+func rowsDriver(rows func() *fakedriver.Rows) *fakedriver.Driver {
+	return &fakedriver.Driver{
+		Query: func(_ context.Context, _ *fakedriver.Conn, _ string, _ []driver.NamedValue) (driver.Rows, error) {
+			return rows(), nil
+		},
+	}
+}
+
+// eventually fails the test if condition does not hold within two seconds.
+func eventually(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,28 @@
+package alphasql
+
+import "context"
+
+// Insert executes an insert query and returns the id generated for the inserted row, so the same code
+// works across the databases reporting it and the ones requiring a separate query to fetch it.
+//
+// The id is taken from [Result.LastInsertID] when the driver supports it. Otherwise, if
+// [ConnectionConfig.InsertIDQuery] is set, it is queried on the same connection right after the insert,
+// for instance `SELECT lastval()`. If the query itself returns the id, like an insert with a RETURNING
+// clause, set [ConnectionConfig.InsertReturnsID] to scan it from the returned row instead.
+func (c *Connection) Insert(ctx context.Context, query string, args ...any) (int64, error) {
+	var id int64
+	if c.cfg.InsertReturnsID {
+		err := c.QueryRow(ctx, query, args...).Scan(ctx, &id)
+		return id, err
+	}
+	r, err := c.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	id, err = r.LastInsertID()
+	if err == nil || c.cfg.InsertIDQuery == "" {
+		return id, err
+	}
+	err = c.QueryRow(ctx, c.cfg.InsertIDQuery).Scan(ctx, &id)
+	return id, err
+}
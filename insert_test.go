@@ -0,0 +1,83 @@
+package alphasql
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+)
+
+const insertQuery = "INSERT INTO users (name) VALUES ($1)"
+
+func TestInsertLastInsertID(t *testing.T) {
+	c := connectFake(t, &fakedriver.Driver{
+		Exec: func(_ context.Context, _ *fakedriver.Conn, _ string, _ []driver.NamedValue) (driver.Result, error) {
+			return fakedriver.Result{ID: 42, Affected: 1}, nil
+		},
+		Query: func(_ context.Context, _ *fakedriver.Conn, query string, _ []driver.NamedValue) (driver.Rows, error) {
+			t.Fatalf("got query %q, want the id taken from the result", query)
+			return nil, nil
+		},
+	}, &ConnectionConfig{InsertIDQuery: "SELECT lastval()"})
+
+	id, err := c.Insert(context.Background(), insertQuery, "a")
+	if err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	if id != 42 {
+		t.Fatalf("got id %d, want 42", id)
+	}
+}
+
+func TestInsertIDQuery(t *testing.T) {
+	var queried string
+	c := connectFake(t, &fakedriver.Driver{
+		Exec: func(_ context.Context, c *fakedriver.Conn, _ string, _ []driver.NamedValue) (driver.Result, error) {
+			c.Set("lastval", "7")
+			return driver.RowsAffected(1), nil
+		},
+		Query: func(_ context.Context, c *fakedriver.Conn, query string, _ []driver.NamedValue) (driver.Rows, error) {
+			queried = query
+			return fakedriver.NewRows([]string{"lastval"}, []driver.Value{c.Get("lastval")}), nil
+		},
+	}, &ConnectionConfig{InsertIDQuery: "SELECT lastval()"})
+
+	id, err := c.Insert(context.Background(), insertQuery, "a")
+	if err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	if id != 7 || queried != "SELECT lastval()" {
+		t.Fatalf("got id %d from %q, want 7 from the id query on the same connection", id, queried)
+	}
+}
+
+func TestInsertWithoutID(t *testing.T) {
+	c := connectFake(t, &fakedriver.Driver{}, nil)
+	if _, err := c.Insert(context.Background(), insertQuery, "a"); err == nil {
+		t.Fatal("got no error, want the driver not reporting the id to fail")
+	}
+}
+
+func TestInsertReturnsID(t *testing.T) {
+	c := connectFake(t, &fakedriver.Driver{
+		Exec: func(_ context.Context, _ *fakedriver.Conn, query string, _ []driver.NamedValue) (driver.Result, error) {
+			t.Fatalf("got execution %q, want the insert queried", query)
+			return nil, nil
+		},
+		Query: func(_ context.Context, _ *fakedriver.Conn, query string, args []driver.NamedValue) (driver.Rows, error) {
+			if query != insertQuery+" RETURNING id" || len(args) != 1 || args[0].Value != "a" {
+				t.Fatalf("got query %q with %v", query, args)
+			}
+			return fakedriver.NewRows([]string{"id"}, []driver.Value{int64(9)}), nil
+		},
+	}, &ConnectionConfig{InsertReturnsID: true})
+
+	id, err := c.Insert(context.Background(), insertQuery+" RETURNING id", "a")
+	if err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	if id != 9 {
+		t.Fatalf("got id %d, want 9", id)
+	}
+}
@@ -0,0 +1,378 @@
+// Package fakedriver provides a scriptable in-memory database/sql/driver implementation, used to test the
+// packages of the module without a database.
+package fakedriver
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Driver is a driver.DriverContext whose connections answer the queries and the executions through its handlers.
+// The handlers left nil succeed, the queries returning no rows. The handlers may be called concurrently, and
+// they must not be changed once the Driver is in use.
+type Driver struct {
+	// Connect is called with the name of the connector each time a connection is established.
+	Connect func(ctx context.Context, name string) error
+
+	// Query answers the queries, either run directly or through a prepared statement.
+	Query func(ctx context.Context, c *Conn, query string, args []driver.NamedValue) (driver.Rows, error)
+
+	// Exec answers the executions, either run directly or through a prepared statement.
+	Exec func(ctx context.Context, c *Conn, query string, args []driver.NamedValue) (driver.Result, error)
+
+	// Prepare is called each time a statement is prepared.
+	Prepare func(c *Conn, query string) error
+
+	// CloseStatement is called each time a statement is closed.
+	CloseStatement func(query string) error
+
+	Ping         func(ctx context.Context, c *Conn) error
+	ResetSession func(ctx context.Context, c *Conn) error
+	IsValid      func(c *Conn) bool
+	Begin        func(c *Conn) error
+	Commit       func(c *Conn) error
+	Rollback     func(c *Conn) error
+
+	// PrepareOnly makes the connections return driver.ErrSkip from QueryContext and ExecContext, so every query and
+	// execution goes through a prepared statement.
+	PrepareOnly bool
+
+	// Minimal makes the connections only implement driver.Conn, without any of the optional interfaces.
+	Minimal bool
+
+	// Connects, Closes, Prepares and StatementCloses count the calls made to the driver.
+	Connects        atomic.Int64
+	Closes          atomic.Int64
+	Prepares        atomic.Int64
+	StatementCloses atomic.Int64
+
+	lastID atomic.Int64
+}
+
+// OpenConnector returns a connector establishing connections named after name.
+func (d *Driver) OpenConnector(name string) (driver.Connector, error) {
+	return &connector{d: d, name: name}, nil
+}
+
+// Open establishes a connection named after name.
+func (d *Driver) Open(name string) (driver.Conn, error) {
+	return d.connect(context.Background(), name)
+}
+
+func (d *Driver) connect(ctx context.Context, name string) (driver.Conn, error) {
+	if d.Connect != nil {
+		if err := d.Connect(ctx, name); err != nil {
+			return nil, err
+		}
+	}
+	d.Connects.Add(1)
+	c := &Conn{ID: d.lastID.Add(1), Name: name, d: d}
+	if d.Minimal {
+		return minimalConn{c}, nil
+	}
+	return c, nil
+}
+
+type connector struct {
+	d    *Driver
+	name string
+}
+
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	return c.d.connect(ctx, c.name)
+}
+
+func (c *connector) Driver() driver.Driver {
+	return c.d
+}
+
+// minimalConn hides the optional interfaces implemented by Conn.
+type minimalConn struct {
+	driver.Conn
+}
+
+// Conn is a connection of a Driver.
+type Conn struct {
+	// ID is the sequence number of the connection within its Driver, starting at 1.
+	ID int64
+	// Name is the name of the connector the connection was established by.
+	Name string
+
+	d      *Driver
+	closed atomic.Bool
+
+	mu      sync.Mutex
+	session map[string]string
+}
+
+// Set stores a value in the session of the connection, for the handlers to keep a per connection state.
+func (c *Conn) Set(key, value string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.session == nil {
+		c.session = make(map[string]string)
+	}
+	c.session[key] = value
+}
+
+// Get returns a value stored in the session of the connection with Set.
+func (c *Conn) Get(key string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.session[key]
+}
+
+// Closed reports whether the connection was closed.
+func (c *Conn) Closed() bool {
+	return c.closed.Load()
+}
+
+func (c *Conn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *Conn) PrepareContext(_ context.Context, query string) (driver.Stmt, error) {
+	if c.d.Prepare != nil {
+		if err := c.d.Prepare(c, query); err != nil {
+			return nil, err
+		}
+	}
+	c.d.Prepares.Add(1)
+	return &Stmt{c: c, query: query}, nil
+}
+
+func (c *Conn) Close() error {
+	if c.closed.CompareAndSwap(false, true) {
+		c.d.Closes.Add(1)
+	}
+	return nil
+}
+
+func (c *Conn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *Conn) BeginTx(_ context.Context, _ driver.TxOptions) (driver.Tx, error) {
+	if c.d.Begin != nil {
+		if err := c.d.Begin(c); err != nil {
+			return nil, err
+		}
+	}
+	return &tx{c: c}, nil
+}
+
+func (c *Conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if c.d.PrepareOnly {
+		return nil, driver.ErrSkip
+	}
+	return c.query(ctx, query, args)
+}
+
+func (c *Conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if c.d.PrepareOnly {
+		return nil, driver.ErrSkip
+	}
+	return c.exec(ctx, query, args)
+}
+
+func (c *Conn) Ping(ctx context.Context) error {
+	if c.d.Ping != nil {
+		return c.d.Ping(ctx, c)
+	}
+	return nil
+}
+
+func (c *Conn) ResetSession(ctx context.Context) error {
+	if c.d.ResetSession != nil {
+		return c.d.ResetSession(ctx, c)
+	}
+	return nil
+}
+
+func (c *Conn) IsValid() bool {
+	if c.d.IsValid != nil {
+		return c.d.IsValid(c)
+	}
+	return true
+}
+
+func (c *Conn) query(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if c.d.Query != nil {
+		return c.d.Query(ctx, c, query, args)
+	}
+	return NewRows(nil), nil
+}
+
+func (c *Conn) exec(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if c.d.Exec != nil {
+		return c.d.Exec(ctx, c, query, args)
+	}
+	return driver.RowsAffected(0), nil
+}
+
+// Stmt is a statement prepared on a Conn.
+type Stmt struct {
+	c     *Conn
+	query string
+}
+
+func (s *Stmt) Close() error {
+	s.c.d.StatementCloses.Add(1)
+	if s.c.d.CloseStatement != nil {
+		return s.c.d.CloseStatement(s.query)
+	}
+	return nil
+}
+
+func (s *Stmt) NumInput() int {
+	return -1
+}
+
+func (s *Stmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.c.exec(context.Background(), s.query, getNamedValues(args))
+}
+
+func (s *Stmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.c.query(context.Background(), s.query, getNamedValues(args))
+}
+
+func (s *Stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	return s.c.exec(ctx, s.query, args)
+}
+
+func (s *Stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	return s.c.query(ctx, s.query, args)
+}
+
+func getNamedValues(args []driver.Value) []driver.NamedValue {
+	nvs := make([]driver.NamedValue, len(args))
+	for i, a := range args {
+		nvs[i] = driver.NamedValue{Ordinal: i + 1, Value: a}
+	}
+	return nvs
+}
+
+type tx struct {
+	c *Conn
+}
+
+func (t *tx) Commit() error {
+	if t.c.d.Commit != nil {
+		return t.c.d.Commit(t.c)
+	}
+	return nil
+}
+
+func (t *tx) Rollback() error {
+	if t.c.d.Rollback != nil {
+		return t.c.d.Rollback(t.c)
+	}
+	return nil
+}
+
+// Result is a driver.Result reporting the id and the count of rows it is set with.
+type Result struct {
+	ID       int64
+	Affected int64
+}
+
+func (r Result) LastInsertId() (int64, error) {
+	return r.ID, nil
+}
+
+func (r Result) RowsAffected() (int64, error) {
+	return r.Affected, nil
+}
+
+// ResultSet is one of the result sets of Rows.
+type ResultSet struct {
+	Columns []string
+	// Types are the database type names of the columns, if any.
+	Types []string
+	Rows  [][]driver.Value
+}
+
+// Rows is a driver.Rows iterating over its result sets.
+type Rows struct {
+	Sets []ResultSet
+	// NextDelay is waited for before returning each row.
+	NextDelay time.Duration
+	// CloseErr is returned by Close.
+	CloseErr error
+
+	set, row int
+	closed   atomic.Bool
+}
+
+// NewRows returns Rows having a single result set with the columns and the rows provided.
+func NewRows(columns []string, rows ...[]driver.Value) *Rows {
+	return &Rows{Sets: []ResultSet{{Columns: columns, Rows: rows}}}
+}
+
+// WithTypes sets the database type names of the columns of the first result set.
+func (r *Rows) WithTypes(types ...string) *Rows {
+	r.Sets[0].Types = types
+	return r
+}
+
+// Closed reports whether the rows were closed.
+func (r *Rows) Closed() bool {
+	return r.closed.Load()
+}
+
+// Drained reports whether all the rows of all the result sets were read.
+func (r *Rows) Drained() bool {
+	return r.set >= len(r.Sets)-1 && (len(r.Sets) == 0 || r.row >= len(r.Sets[r.set].Rows))
+}
+
+func (r *Rows) Columns() []string {
+	if r.set >= len(r.Sets) {
+		return nil
+	}
+	return r.Sets[r.set].Columns
+}
+
+func (r *Rows) Close() error {
+	r.closed.Store(true)
+	return r.CloseErr
+}
+
+func (r *Rows) Next(dest []driver.Value) error {
+	if r.closed.Load() {
+		return errors.New("fakedriver: rows are closed")
+	}
+	if r.set >= len(r.Sets) || r.row >= len(r.Sets[r.set].Rows) {
+		return io.EOF
+	}
+	if r.NextDelay > 0 {
+		time.Sleep(r.NextDelay)
+	}
+	copy(dest, r.Sets[r.set].Rows[r.row])
+	r.row++
+	return nil
+}
+
+func (r *Rows) HasNextResultSet() bool {
+	return r.set < len(r.Sets)-1
+}
+
+func (r *Rows) NextResultSet() error {
+	if !r.HasNextResultSet() {
+		return io.EOF
+	}
+	r.set++
+	r.row = 0
+	return nil
+}
+
+func (r *Rows) ColumnTypeDatabaseTypeName(index int) string {
+	if r.set >= len(r.Sets) || index >= len(r.Sets[r.set].Types) {
+		return ""
+	}
+	return r.Sets[r.set].Types[index]
+}
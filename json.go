@@ -0,0 +1,41 @@
+package alphasql
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// JSON wraps a value of type T stored in a JSON or JSONB column. It can be used both as a scan destination,
+// unmarshaling the JSON document of the column into Data, and as a query arg, marshaling Data into a JSON document.
+type JSON[T any] struct {
+	Data  T
+	Valid bool // Valid is true if the column is not NULL
+}
+
+// Scan implements the [Scanner] interface.
+func (j *JSON[T]) Scan(src any) error {
+	var zero T
+	j.Data = zero
+	switch s := src.(type) {
+	case nil:
+		j.Valid = false
+		return nil
+	case []byte:
+		j.Valid = true
+		return json.Unmarshal(s, &j.Data)
+	case string:
+		j.Valid = true
+		return json.Unmarshal([]byte(s), &j.Data)
+	}
+	j.Valid = false
+	return fmt.Errorf("converting driver.Value type %T to a JSON: %w", src, ErrRowsUnsupportedScan)
+}
+
+// Value implements the [driver.Valuer] interface.
+func (j JSON[T]) Value() (driver.Value, error) {
+	if !j.Valid {
+		return nil, nil
+	}
+	return json.Marshal(j.Data)
+}
@@ -0,0 +1,71 @@
+package alphasql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+)
+
+type settings struct {
+	Theme string   `json:"theme"`
+	Tags  []string `json:"tags"`
+}
+
+const settingsJSON = `{"theme":"dark","tags":["a","b"]}`
+
+func TestJSONScan(t *testing.T) {
+	for _, src := range []driver.Value{settingsJSON, []byte(settingsJSON)} {
+		var m JSON[map[string]any]
+		if err := queryOne(t, nil, src).Scan(&m); err != nil {
+			t.Fatalf("%T: scan into a map: %v", src, err)
+		}
+		if !m.Valid || m.Data["theme"] != "dark" || len(m.Data["tags"].([]any)) != 2 {
+			t.Fatalf("%T: got %+v, want the settings", src, m)
+		}
+
+		var s JSON[settings]
+		if err := queryOne(t, nil, src).Scan(&s); err != nil {
+			t.Fatalf("%T: scan into a struct: %v", src, err)
+		}
+		if !s.Valid || s.Data.Theme != "dark" || len(s.Data.Tags) != 2 || s.Data.Tags[1] != "b" {
+			t.Fatalf("%T: got %+v, want the settings", src, s)
+		}
+	}
+}
+
+func TestJSONScanNull(t *testing.T) {
+	s := JSON[settings]{Data: settings{Theme: "light"}, Valid: true}
+	if err := queryOne(t, nil, nil).Scan(&s); err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	if s.Valid || s.Data.Theme != "" {
+		t.Fatalf("got %+v, want the zero value", s)
+	}
+}
+
+func TestJSONScanUnsupported(t *testing.T) {
+	var s JSON[settings]
+	if err := queryOne(t, nil, int64(1)).Scan(&s); !errors.Is(err, ErrRowsUnsupportedScan) {
+		t.Fatalf("got %v, want %v", err, ErrRowsUnsupportedScan)
+	}
+	if s.Valid {
+		t.Fatalf("got %+v, want it invalid", s)
+	}
+}
+
+func TestJSONArg(t *testing.T) {
+	ctx := context.Background()
+	c := connectFake(t, echoDriver(), nil)
+	in := JSON[settings]{Data: settings{Theme: "dark", Tags: []string{"a", "b"}}, Valid: true}
+	var raw, null any
+	if err := c.QueryRow(ctx, "SELECT $1, $2", in, JSON[settings]{}).Scan(ctx, &raw, &null); err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	if b, ok := raw.([]byte); !ok || string(b) != settingsJSON {
+		t.Fatalf("got %v, want %s", raw, settingsJSON)
+	}
+	if null != nil {
+		t.Fatalf("got %v for an invalid JSON, want NULL", null)
+	}
+}
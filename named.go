@@ -0,0 +1,84 @@
+package alphasql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+)
+
+type namedStatement struct {
+	query string
+	s     driver.Stmt
+}
+
+// ExecNamed executes a query without returning any rows, like [Connection.Exec], preparing it under the name
+// provided the first time, and reusing the prepared statement on the subsequent calls with the same name, so
+// databases caching the plans of the prepared statements skip the parse and plan steps.
+// If the query of a name changes, the statement is prepared again.
+// If the driver does not support preparing the query, it is executed as [Connection.Exec] does.
+func (c *Connection) ExecNamed(ctx context.Context, name, query string, args ...any) (Result, error) {
+	if err := c.validateQuery(query); err != nil {
+		return nil, newQueryError(ctx, c.cfg, query, err)
+	}
+	s, err := c.getNamedStatement(ctx, name, query)
+	if errors.Is(err, driver.ErrSkip) {
+		return c.Exec(ctx, query, args...)
+	}
+	var r driver.Result
+	if err == nil {
+		var nvs []driver.NamedValue
+		nvs, err = getDriverNamedValuesFromArgs(c, args)
+		if err == nil {
+			r, err = execUsingDriverStatement(ctx, s, nvs)
+		}
+	}
+	if errors.Is(err, driver.ErrBadConn) {
+		err = ErrBadConnection
+	}
+	if err != nil {
+		if errors.Is(err, ErrBadConnection) {
+			c.removeNamedStatement(name)
+		}
+		return nil, newQueryError(ctx, c.cfg, query, err)
+	}
+	return &result{r: r}, nil
+}
+
+func (c *Connection) getNamedStatement(ctx context.Context, name, query string) (driver.Stmt, error) {
+	c.namedStatementsMu.Lock()
+	defer c.namedStatementsMu.Unlock()
+	if ns, ok := c.namedStatements[name]; ok {
+		if ns.query == query {
+			return ns.s, nil
+		}
+		_ = ns.s.Close()
+		delete(c.namedStatements, name)
+	}
+	s, err := getDriverStatement(ctx, c, query)
+	if err != nil {
+		return nil, err
+	}
+	if c.namedStatements == nil {
+		c.namedStatements = make(map[string]*namedStatement)
+	}
+	c.namedStatements[name] = &namedStatement{query: query, s: s}
+	return s, nil
+}
+
+func (c *Connection) removeNamedStatement(name string) {
+	c.namedStatementsMu.Lock()
+	defer c.namedStatementsMu.Unlock()
+	if ns, ok := c.namedStatements[name]; ok {
+		_ = ns.s.Close()
+		delete(c.namedStatements, name)
+	}
+}
+
+func (c *Connection) closeNamedStatements() {
+	c.namedStatementsMu.Lock()
+	defer c.namedStatementsMu.Unlock()
+	for name, ns := range c.namedStatements {
+		_ = ns.s.Close()
+		delete(c.namedStatements, name)
+	}
+}
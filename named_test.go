@@ -0,0 +1,66 @@
+package alphasql
+
+import (
+	"context"
+	"database/sql/driver"
+	"sync/atomic"
+	"testing"
+
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+)
+
+// parseCountingDriver returns a fake driver counting the executions, each prepare standing for a parse.
+func parseCountingDriver(execs *atomic.Int64) *fakedriver.Driver {
+	return &fakedriver.Driver{
+		Exec: func(_ context.Context, _ *fakedriver.Conn, _ string, _ []driver.NamedValue) (driver.Result, error) {
+			execs.Add(1)
+			return driver.RowsAffected(1), nil
+		},
+	}
+}
+
+func TestExecNamedParsesOnce(t *testing.T) {
+	var execs atomic.Int64
+	d := parseCountingDriver(&execs)
+	c := connectFake(t, d, nil)
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		if _, err := c.ExecNamed(ctx, "rename", "UPDATE users SET name = $1 WHERE id = $2", "a", i); err != nil {
+			t.Fatalf("exec named: %v", err)
+		}
+	}
+	if n := d.Prepares.Load(); n != 1 {
+		t.Fatalf("got %d parses, want 1", n)
+	}
+	if n := execs.Load(); n != 3 {
+		t.Fatalf("got %d executions, want 3", n)
+	}
+
+	if _, err := c.ExecNamed(ctx, "rename", "UPDATE users SET name = $1 WHERE id = $2 AND 1 = 1", "a", 1); err != nil {
+		t.Fatalf("exec named: %v", err)
+	}
+	if n := d.Prepares.Load(); n != 2 {
+		t.Fatalf("got %d parses after the query of the name changed, want 2", n)
+	}
+	if n := d.StatementCloses.Load(); n != 1 {
+		t.Fatalf("got %d statements closed, want the one of the previous query", n)
+	}
+}
+
+func TestExecNamedFallsBackWithoutPrepare(t *testing.T) {
+	var execs atomic.Int64
+	d := parseCountingDriver(&execs)
+	d.Prepare = func(_ *fakedriver.Conn, _ string) error { return driver.ErrSkip }
+	c := connectFake(t, d, nil)
+	for i := 0; i < 2; i++ {
+		if _, err := c.ExecNamed(context.Background(), "rename", "UPDATE users SET name = $1", "a"); err != nil {
+			t.Fatalf("exec named: %v", err)
+		}
+	}
+	if n := execs.Load(); n != 2 {
+		t.Fatalf("got %d executions, want 2", n)
+	}
+	if n := d.Prepares.Load(); n != 0 {
+		t.Fatalf("got %d statements prepared, want 0", n)
+	}
+}
@@ -0,0 +1,126 @@
+package alphasql
+
+import (
+	"database/sql/driver"
+	"time"
+)
+
+// NullString represents a string that may be NULL. It can be used both as a scan destination and as a query arg.
+type NullString struct {
+	String string
+	Valid  bool // Valid is true if String is not NULL
+}
+
+// NullInt64 represents an int64 that may be NULL. It can be used both as a scan destination and as a query arg.
+type NullInt64 struct {
+	Int64 int64
+	Valid bool // Valid is true if Int64 is not NULL
+}
+
+// NullFloat64 represents a float64 that may be NULL. It can be used both as a scan destination and as a query arg.
+type NullFloat64 struct {
+	Float64 float64
+	Valid   bool // Valid is true if Float64 is not NULL
+}
+
+// NullBool represents a bool that may be NULL. It can be used both as a scan destination and as a query arg.
+type NullBool struct {
+	Bool  bool
+	Valid bool // Valid is true if Bool is not NULL
+}
+
+// NullTime represents a [time.Time] that may be NULL. It can be used both as a scan destination and as a query arg.
+type NullTime struct {
+	Time  time.Time
+	Valid bool // Valid is true if Time is not NULL
+}
+
+// Scan implements the [Scanner] interface.
+func (n *NullString) Scan(src any) error {
+	if src == nil {
+		n.String, n.Valid = "", false
+		return nil
+	}
+	n.Valid = true
+	return convertAssignRows(src, &n.String)
+}
+
+// Value implements the [driver.Valuer] interface.
+func (n NullString) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.String, nil
+}
+
+// Scan implements the [Scanner] interface.
+func (n *NullInt64) Scan(src any) error {
+	if src == nil {
+		n.Int64, n.Valid = 0, false
+		return nil
+	}
+	n.Valid = true
+	return convertAssignRows(src, &n.Int64)
+}
+
+// Value implements the [driver.Valuer] interface.
+func (n NullInt64) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Int64, nil
+}
+
+// Scan implements the [Scanner] interface.
+func (n *NullFloat64) Scan(src any) error {
+	if src == nil {
+		n.Float64, n.Valid = 0, false
+		return nil
+	}
+	n.Valid = true
+	return convertAssignRows(src, &n.Float64)
+}
+
+// Value implements the [driver.Valuer] interface.
+func (n NullFloat64) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Float64, nil
+}
+
+// Scan implements the [Scanner] interface.
+func (n *NullBool) Scan(src any) error {
+	if src == nil {
+		n.Bool, n.Valid = false, false
+		return nil
+	}
+	n.Valid = true
+	return convertAssignRows(src, &n.Bool)
+}
+
+// Value implements the [driver.Valuer] interface.
+func (n NullBool) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Bool, nil
+}
+
+// Scan implements the [Scanner] interface.
+func (n *NullTime) Scan(src any) error {
+	if src == nil {
+		n.Time, n.Valid = time.Time{}, false
+		return nil
+	}
+	n.Valid = true
+	return convertAssignRows(src, &n.Time)
+}
+
+// Value implements the [driver.Valuer] interface.
+func (n NullTime) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Time, nil
+}
@@ -0,0 +1,77 @@
+package alphasql
+
+import (
+	"context"
+	"database/sql/driver"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+)
+
+// echoDriver returns a fake driver answering every query with a single row made of the args of the query.
+func echoDriver() *fakedriver.Driver {
+	return &fakedriver.Driver{
+		Query: func(_ context.Context, _ *fakedriver.Conn, _ string, args []driver.NamedValue) (driver.Rows, error) {
+			columns := make([]string, len(args))
+			row := make([]driver.Value, len(args))
+			for i, a := range args {
+				columns[i] = "c" + strconv.Itoa(i)
+				row[i] = a.Value
+			}
+			return fakedriver.NewRows(columns, row), nil
+		},
+	}
+}
+
+func TestNullRoundTrip(t *testing.T) {
+	c := connectFake(t, echoDriver(), nil)
+	ctx := context.Background()
+	at := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+
+	in := []any{
+		NullString{String: "a", Valid: true},
+		NullInt64{Int64: 42, Valid: true},
+		NullFloat64{Float64: 1.5, Valid: true},
+		NullBool{Bool: true, Valid: true},
+		NullTime{Time: at, Valid: true},
+	}
+	var s NullString
+	var i NullInt64
+	var f NullFloat64
+	var b NullBool
+	var tm NullTime
+	if err := c.QueryRow(ctx, "SELECT $1, $2, $3, $4, $5", in...).Scan(ctx, &s, &i, &f, &b, &tm); err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	if s != in[0] || i != in[1] || f != in[2] || b != in[3] || !tm.Valid || !tm.Time.Equal(at) {
+		t.Fatalf("got %v %v %v %v %v, want %v", s, i, f, b, tm, in)
+	}
+
+	in = []any{NullString{}, NullInt64{}, NullFloat64{}, NullBool{}, NullTime{}}
+	if err := c.QueryRow(ctx, "SELECT $1, $2, $3, $4, $5", in...).Scan(ctx, &s, &i, &f, &b, &tm); err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	if s.Valid || i.Valid || f.Valid || b.Valid || tm.Valid {
+		t.Fatalf("got %v %v %v %v %v, want all of them invalid", s, i, f, b, tm)
+	}
+	if s != (NullString{}) || i != (NullInt64{}) || f != (NullFloat64{}) || b != (NullBool{}) || !tm.Time.IsZero() {
+		t.Fatalf("got %v %v %v %v %v, want the previous values reset", s, i, f, b, tm)
+	}
+}
+
+func TestNullScanConverts(t *testing.T) {
+	c := connectFake(t, rowsDriver(func() *fakedriver.Rows {
+		return fakedriver.NewRows([]string{"id", "active"}, []driver.Value{"7", int64(1)})
+	}), nil)
+	ctx := context.Background()
+	var i NullInt64
+	var b NullBool
+	if err := c.QueryRow(ctx, "SELECT id, active FROM users").Scan(ctx, &i, &b); err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	if i != (NullInt64{Int64: 7, Valid: true}) || b != (NullBool{Bool: true, Valid: true}) {
+		t.Fatalf("got %v %v, want 7 and true", i, b)
+	}
+}
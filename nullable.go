@@ -0,0 +1,35 @@
+package alphasql
+
+import "context"
+
+// nullable scans a possibly NULL column into a value, recording whether it was NULL.
+type nullable[T any] struct {
+	value *T
+	valid bool
+}
+
+// Scan implements the [Scanner] interface.
+func (n *nullable[T]) Scan(src any) error {
+	if src == nil {
+		var zero T
+		*n.value = zero
+		n.valid = false
+		return nil
+	}
+	n.valid = true
+	return convertAssignRows(src, n.value)
+}
+
+// ScanOneNullable scans the single column of the row into a value of type T, reporting whether the column
+// was not NULL. A NULL column, like the result of an aggregate such as SUM over an empty set, leaves the value
+// as the zero value of T instead of failing the conversion, as scanning it into a *T with [Row.Scan] does.
+//
+// Like [Row.Scan], it returns [ErrNoRows] if the query selected no rows.
+func ScanOneNullable[T any](ctx context.Context, r Row) (T, bool, error) {
+	var v T
+	n := nullable[T]{value: &v}
+	if err := r.Scan(ctx, &n); err != nil {
+		return v, false, err
+	}
+	return v, n.valid, nil
+}
@@ -0,0 +1,64 @@
+package alphasql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+)
+
+// sumDriver returns a fake driver answering every query with the sum of the amounts provided, NULL when empty.
+func sumDriver(amounts ...int64) *fakedriver.Driver {
+	return rowsDriver(func() *fakedriver.Rows {
+		var sum driver.Value
+		for _, a := range amounts {
+			s, _ := sum.(int64)
+			sum = s + a
+		}
+		return fakedriver.NewRows([]string{"sum"}, []driver.Value{sum})
+	})
+}
+
+func TestScanOneNullable(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		amounts []int64
+		want    int64
+		valid   bool
+	}{
+		{"empty table", nil, 0, false},
+		{"some rows", []int64{3, 4}, 7, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := connectFake(t, sumDriver(tc.amounts...), nil)
+			ctx := context.Background()
+			got, valid, err := ScanOneNullable[int64](ctx, c.QueryRow(ctx, "SELECT SUM(amount) FROM payments"))
+			if err != nil {
+				t.Fatalf("scan: %v", err)
+			}
+			if got != tc.want || valid != tc.valid {
+				t.Fatalf("got %d valid %t, want %d valid %t", got, valid, tc.want, tc.valid)
+			}
+		})
+	}
+}
+
+func TestScanOneNullableWithoutHelper(t *testing.T) {
+	c := connectFake(t, sumDriver(), nil)
+	ctx := context.Background()
+	var sum int64
+	if err := c.QueryRow(ctx, "SELECT SUM(amount) FROM payments").Scan(ctx, &sum); !errors.Is(err, ErrRowsUnexpectedScan) {
+		t.Fatalf("got %v, want %v scanning NULL into an int64", err, ErrRowsUnexpectedScan)
+	}
+}
+
+func TestScanOneNullableNoRows(t *testing.T) {
+	c := connectFake(t, rowsDriver(func() *fakedriver.Rows { return fakedriver.NewRows([]string{"sum"}) }), nil)
+	ctx := context.Background()
+	_, _, err := ScanOneNullable[int64](ctx, c.QueryRow(ctx, "SELECT amount FROM payments WHERE id = 1"))
+	if !errors.Is(err, ErrNoRows) {
+		t.Fatalf("got %v, want %v", err, ErrNoRows)
+	}
+}
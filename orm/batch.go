@@ -0,0 +1,163 @@
+package orm
+
+import (
+	"context"
+	"fmt"
+	alphasql "github.com/sinhashubham95/alpha-sql"
+	"github.com/sinhashubham95/alpha-sql/orm/entity"
+	"strings"
+)
+
+func (o *orm) BatchSave(ctx context.Context, conflictColumns []string, es ...entity.Entity) error {
+	if len(es) == 0 {
+		return nil
+	}
+	query, args, doNothing, err := getBatchSaveQuery(conflictColumns, es)
+	if err != nil {
+		return err
+	}
+	r, err := o.p.Exec(ctx, query, args...)
+	if err != nil || doNothing {
+		return err
+	}
+	return o.checkRowsAffected(r)
+}
+
+func (t *transactionalORM) BatchSave(ctx context.Context, conflictColumns []string, es ...entity.Entity) error {
+	if len(es) == 0 {
+		return nil
+	}
+	query, args, doNothing, err := getBatchSaveQuery(conflictColumns, es)
+	if err != nil {
+		return err
+	}
+	r, err := t.tx.Exec(ctx, query, args...)
+	if err != nil || doNothing {
+		return err
+	}
+	return t.o.checkRowsAffected(r)
+}
+
+func (o *orm) checkRowsAffected(r alphasql.Result) error {
+	if !o.failOnNoRowsAffected {
+		return nil
+	}
+	rows, err := r.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return alphasql.ErrNoRowsAffected
+	}
+	return nil
+}
+
+// checkAllRowsAffected makes sure a statement affecting several entities at once affected a row for each of them.
+func (o *orm) checkAllRowsAffected(r alphasql.Result, count int) error {
+	if !o.failOnNoRowsAffected {
+		return nil
+	}
+	rows, err := r.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows < int64(count) {
+		return alphasql.ErrNoRowsAffected
+	}
+	return nil
+}
+
+// isMultiFreshSave reports whether the entities are freshly saved using a single multi-row insert, which is the case
+// when there are several of them and all of them implement entity.MultiFreshSaveEntity.
+func isMultiFreshSave(es []entity.Entity) bool {
+	if len(es) < 2 {
+		return false
+	}
+	for _, e := range es {
+		if _, ok := e.(entity.MultiFreshSaveEntity); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// getMultiFreshSaveQuery builds the multi-row insert query for the entities, which must be multi fresh saved as
+// reported by isMultiFreshSave.
+func getMultiFreshSaveQuery(es []entity.Entity) (string, []any) {
+	args := make([]any, 0, len(es))
+	for _, e := range es {
+		args = append(args, e.(entity.MultiFreshSaveEntity).GetMultiFreshSaveArgs()...)
+	}
+	return es[0].(entity.MultiFreshSaveEntity).GetMultiFreshSaveQuery(len(es)), args
+}
+
+// getBatchSaveQuery builds a single INSERT ... ON CONFLICT ... DO UPDATE query for all the entities,
+// using the numbered placeholders($1, $2, ...) for the values. Unlike the other queries of the ORM, provided by the
+// entities, it hard-codes the PostgreSQL dialect. It reports whether the conflicts are resolved with DO NOTHING,
+// which is the case when there are no columns left to update.
+func getBatchSaveQuery(conflictColumns []string, es []entity.Entity) (string, []any, bool, error) {
+	if len(conflictColumns) == 0 {
+		return "", nil, false, alphasql.ErrBatchSaveNoConflictColumns
+	}
+	first, ok := es[0].(entity.BatchSaveEntity)
+	if !ok {
+		return "", nil, false, alphasql.ErrBatchSaveNotSupported
+	}
+	columns := first.GetColumns()
+	var sb strings.Builder
+	sb.WriteString("INSERT INTO ")
+	sb.WriteString(first.GetTableName())
+	sb.WriteString(" (")
+	sb.WriteString(strings.Join(columns, ", "))
+	sb.WriteString(") VALUES ")
+	args := make([]any, 0, len(es)*len(columns))
+	for i, e := range es {
+		be, ok := e.(entity.BatchSaveEntity)
+		if !ok {
+			return "", nil, false, alphasql.ErrBatchSaveNotSupported
+		}
+		values := be.GetValues()
+		if len(values) != len(columns) {
+			return "", nil, false, fmt.Errorf("%w: entity %d has %d values for %d columns",
+				alphasql.ErrBatchSaveValuesMismatch, i, len(values), len(columns))
+		}
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString("(")
+		for j := range values {
+			if j > 0 {
+				sb.WriteString(", ")
+			}
+			args = append(args, values[j])
+			fmt.Fprintf(&sb, "$%d", len(args))
+		}
+		sb.WriteString(")")
+	}
+	sb.WriteString(" ON CONFLICT (")
+	sb.WriteString(strings.Join(conflictColumns, ", "))
+	sb.WriteString(") DO ")
+	updates := getBatchSaveUpdates(columns, conflictColumns)
+	if len(updates) == 0 {
+		sb.WriteString("NOTHING")
+	} else {
+		sb.WriteString("UPDATE SET ")
+		sb.WriteString(strings.Join(updates, ", "))
+	}
+	return sb.String(), args, len(updates) == 0, nil
+}
+
+func getBatchSaveUpdates(columns, conflictColumns []string) []string {
+	conflicting := make(map[string]struct{}, len(conflictColumns))
+	for _, c := range conflictColumns {
+		conflicting[c] = struct{}{}
+	}
+	updates := make([]string, 0, len(columns))
+	for _, c := range columns {
+		if _, ok := conflicting[c]; ok {
+			continue
+		}
+		updates = append(updates, c+" = EXCLUDED."+c)
+	}
+	return updates
+}
@@ -0,0 +1,150 @@
+package orm
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	alphasql "github.com/sinhashubham95/alpha-sql"
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+	"github.com/sinhashubham95/alpha-sql/orm/entity"
+)
+
+// batchUser is a user which may be saved in batches.
+type batchUser struct {
+	user
+}
+
+func (u *batchUser) GetTableName() string {
+	return "users"
+}
+
+func (u *batchUser) GetColumns() []string {
+	return []string{"id", "name"}
+}
+
+func (u *batchUser) GetValues() []interface{} {
+	return []interface{}{u.ID, u.Name}
+}
+
+// upsertTable is a users table upserted by the batch saves run against its fake driver, keyed by the id.
+type upsertTable struct {
+	mu      sync.Mutex
+	names   map[int64]string
+	queries []string
+}
+
+func (u *upsertTable) driver() *fakedriver.Driver {
+	return &fakedriver.Driver{
+		Exec: func(_ context.Context, _ *fakedriver.Conn, query string, args []driver.NamedValue) (driver.Result, error) {
+			u.mu.Lock()
+			defer u.mu.Unlock()
+			u.queries = append(u.queries, query)
+			doNothing := strings.HasSuffix(query, "DO NOTHING")
+			var affected int64
+			for i := 0; i+1 < len(args); i += 2 {
+				id, name := args[i].Value.(int64), args[i+1].Value.(string)
+				if _, ok := u.names[id]; ok && doNothing {
+					continue
+				}
+				u.names[id] = name
+				affected++
+			}
+			return driver.RowsAffected(affected), nil
+		},
+	}
+}
+
+func TestBatchSave(t *testing.T) {
+	table := &upsertTable{names: map[int64]string{1: "a", 2: "b"}}
+	o := newFakeORM(t, table.driver(), &Configuration{FailOnNoRowsAffected: true})
+
+	err := o.BatchSave(context.Background(), []string{"id"},
+		&batchUser{user{ID: 1, Name: "x"}}, &batchUser{user{ID: 3, Name: "c"}}, &batchUser{user{ID: 4, Name: "d"}})
+	if err != nil {
+		t.Fatalf("batch save: %v", err)
+	}
+	if len(table.queries) != 1 {
+		t.Fatalf("got %d queries, want a single one", len(table.queries))
+	}
+	want := "INSERT INTO users (id, name) VALUES ($1, $2), ($3, $4), ($5, $6) ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name"
+	if table.queries[0] != want {
+		t.Fatalf("got query %q, want %q", table.queries[0], want)
+	}
+	for id, name := range map[int64]string{1: "x", 2: "b", 3: "c", 4: "d"} {
+		if table.names[id] != name {
+			t.Fatalf("got user %d named %q, want %q", id, table.names[id], name)
+		}
+	}
+}
+
+func TestBatchSaveDoNothing(t *testing.T) {
+	table := &upsertTable{names: map[int64]string{1: "a"}}
+	o := newFakeORM(t, table.driver(), &Configuration{FailOnNoRowsAffected: true})
+
+	if err := o.BatchSave(context.Background(), []string{"id", "name"}, &batchUser{user{ID: 1, Name: "x"}}); err != nil {
+		t.Fatalf("batch save: %v", err)
+	}
+	want := "INSERT INTO users (id, name) VALUES ($1, $2) ON CONFLICT (id, name) DO NOTHING"
+	if len(table.queries) != 1 || table.queries[0] != want {
+		t.Fatalf("got queries %q, want %q", table.queries, want)
+	}
+	if table.names[1] != "a" {
+		t.Fatalf("got user 1 named %q, want it left untouched", table.names[1])
+	}
+}
+
+func TestBatchSaveInTransaction(t *testing.T) {
+	table := &upsertTable{names: map[int64]string{1: "a"}}
+	o := newFakeORM(t, table.driver(), nil)
+
+	err := o.TransactionFunc(context.Background(), nil, func(tx TransactionalORM) error {
+		return tx.BatchSave(context.Background(), []string{"id"}, &batchUser{user{ID: 1, Name: "x"}}, &batchUser{user{ID: 2, Name: "b"}})
+	})
+	if err != nil {
+		t.Fatalf("transaction: %v", err)
+	}
+	if table.names[1] != "x" || table.names[2] != "b" {
+		t.Fatalf("got %v, want user 1 updated and user 2 inserted", table.names)
+	}
+}
+
+func TestBatchSaveErrors(t *testing.T) {
+	id := []string{"id"}
+	for _, tc := range []struct {
+		name      string
+		conflicts []string
+		es        []entity.Entity
+		err       error
+	}{
+		{"not supported", id, []entity.Entity{&user{ID: 1}}, alphasql.ErrBatchSaveNotSupported},
+		{"not supported after the first", id, []entity.Entity{&batchUser{user{ID: 1}}, &user{ID: 2}},
+			alphasql.ErrBatchSaveNotSupported},
+		{"values mismatch", id, []entity.Entity{&mismatchedBatchUser{batchUser{user{ID: 1}}}},
+			alphasql.ErrBatchSaveValuesMismatch},
+		{"no conflict columns", nil, []entity.Entity{&batchUser{user{ID: 1}}}, alphasql.ErrBatchSaveNoConflictColumns},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			table := &upsertTable{names: map[int64]string{}}
+			o := newFakeORM(t, table.driver(), nil)
+			if err := o.BatchSave(context.Background(), tc.conflicts, tc.es...); !errors.Is(err, tc.err) {
+				t.Fatalf("got %v, want %v", err, tc.err)
+			}
+			if len(table.queries) != 0 {
+				t.Fatalf("got queries %q, want none", table.queries)
+			}
+		})
+	}
+}
+
+// mismatchedBatchUser returns fewer values than columns.
+type mismatchedBatchUser struct {
+	batchUser
+}
+
+func (u *mismatchedBatchUser) GetValues() []interface{} {
+	return []interface{}{u.ID}
+}
@@ -0,0 +1,48 @@
+package orm
+
+import (
+	"context"
+	"errors"
+	alphasql "github.com/sinhashubham95/alpha-sql"
+	"github.com/sinhashubham95/alpha-sql/orm/entity"
+)
+
+func (o *orm) Count(ctx context.Context, e entity.Entity) (int64, error) {
+	return count(ctx, o.querier(ctx), e)
+}
+
+func (t *transactionalORM) Count(ctx context.Context, e entity.Entity) (int64, error) {
+	return count(ctx, t.tx, e)
+}
+
+func (o *orm) Exists(ctx context.Context, e entity.Entity) (bool, error) {
+	return exists(ctx, o.querier(ctx), e)
+}
+
+func (t *transactionalORM) Exists(ctx context.Context, e entity.Entity) (bool, error) {
+	return exists(ctx, t.tx, e)
+}
+
+func count(ctx context.Context, q querier, e entity.Entity) (int64, error) {
+	var c int64
+	err := q.QueryRow(ctx, e.GetCountQuery(), e.GetCountArgs()...).Scan(ctx, &c)
+	if errors.Is(err, alphasql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return c, nil
+}
+
+func exists(ctx context.Context, q querier, e entity.Entity) (bool, error) {
+	var ok bool
+	err := q.QueryRow(ctx, e.GetExistsQuery(), e.GetExistsArgs()...).Scan(ctx, &ok)
+	if errors.Is(err, alphasql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return ok, nil
+}
@@ -8,23 +8,94 @@ type Scanner interface {
 	ScanStructure(ctx context.Context, value interface{}) error
 }
 
-// Entity is used to provide the set of functionalities common around database operations on a table.
-type Entity interface {
+// ReadableEntity is used to provide the set of functionalities around fetching the data of an entity, shared by the
+// entities and the view entities, so both can be fetched by a primary key using GetByID.
+type ReadableEntity interface {
 	GetIDQuery() string
 	GetIDArgs() []interface{}
 	GetAllQuery() string
 	GetAllQueryArgs() []interface{}
-	GetNext() Entity
 	BindRow(row Scanner) error
+}
+
+// Entity is used to provide the set of functionalities common around database operations on a table.
+type Entity interface {
+	ReadableEntity
+	// GetAllPagedQuery returns the query and its args fetching a page of at most limit rows, after skipping offset
+	// rows, in the dialect of the database. A limit of 0 means no limit.
+	GetAllPagedQuery(limit, offset int) (string, []interface{})
+	GetNext() Entity
 	GetFreshSaveQuery() string
 	GetFreshSaveArgs() []interface{}
 	GetSaveQuery() string
 	GetSaveArgs() []interface{}
 	GetDeleteQuery() string
 	GetDeleteArgs() []interface{}
+	GetDeleteAllQuery() string
+	GetCountQuery() string
+	GetCountArgs() []interface{}
+	GetExistsQuery() string
+	GetExistsArgs() []interface{}
+}
+
+// ViewEntity is used to provide the set of read-only functionalities around a read model, such as a view or a join
+// of several tables, without having to implement the write operations of an Entity.
+type ViewEntity interface {
+	ReadableEntity
+	GetNextView() ViewEntity
+}
+
+// VersionedEntity is used to provide the optimistic locking of an entity carrying a version column. Its versioned
+// save query must only update the row having the version returned by GetVersion, typically with a
+// `WHERE version = ?` condition, and bump the version, so a save based on a stale version affects no rows.
+type VersionedEntity interface {
+	Entity
+	GetVersion() int64
+	GetVersionedSaveQuery() string
+	GetVersionedSaveArgs() []interface{}
+}
+
+// Hookable is used to provide the lifecycle hooks of an entity, such as setting its timestamps or validating it,
+// invoked by the ORM around saving(FreshSave and Save) and deleting it. An error returned by a before hook aborts
+// the operation, rolling back its transaction. The after hooks are invoked by the ORM once the transaction of the
+// operation commits, while within a TransactionalORM they are invoked once the statements of the operation are
+// executed, before the enclosing transaction commits.
+type Hookable interface {
+	Entity
+	BeforeSave(ctx context.Context) error
+	AfterSave(ctx context.Context) error
+	BeforeDelete(ctx context.Context) error
+	AfterDelete(ctx context.Context) error
+}
+
+// TruncatableEntity is used to provide the query to truncate the table of an entity, used to delete all the data of
+// the entity when the ORM is configured to truncate.
+type TruncatableEntity interface {
+	Entity
+	GetTruncateQuery() string
+}
+
+// MultiFreshSaveEntity is used to provide the query to freshly save(insert) several entities at once using a single
+// multi-row insert, used by FreshSave when more than one entity is passed.
+type MultiFreshSaveEntity interface {
+	Entity
+	// GetMultiFreshSaveQuery returns the query inserting n entities, binding the args of each of them in order.
+	GetMultiFreshSaveQuery(n int) string
+	GetMultiFreshSaveArgs() []interface{}
+}
+
+// BatchSaveEntity is used to provide the table details of an entity needed to save(upsert) several entities at once.
+type BatchSaveEntity interface {
+	Entity
+	GetTableName() string
+	GetColumns() []string
+	GetValues() []interface{}
 }
 
 // RawEntity is used to provide the set of raw functionalities around the database operations on a table.
+//
+// The args returned may contain alphasql.NamedArg values, such as the ones created using alphasql.Named,
+// binding to the corresponding named parameters like @name in the queries, for the drivers supporting them.
 type RawEntity interface {
 	GetQueryRow(code int) string
 	GetQueryRowArgs(code int) []interface{}
@@ -40,4 +111,7 @@ type RawEntity interface {
 type RawExec struct {
 	Entity RawEntity
 	Code   int
+	// Args, when set, is a structure, or a pointer to one, whose fields become the named args of the execution, as
+	// per alphasql.StructureArgs, instead of the args returned by the GetExecArgs of the entity.
+	Args interface{}
 }
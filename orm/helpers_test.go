@@ -0,0 +1,184 @@
+package orm
+
+import (
+	"context"
+	"database/sql/driver"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	alphasql "github.com/sinhashubham95/alpha-sql"
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+	"github.com/sinhashubham95/alpha-sql/orm/entity"
+	"github.com/sinhashubham95/alpha-sql/pool"
+)
+
+// fakeDrivers numbers the fake drivers registered, so a test may register several of them.
+var fakeDrivers atomic.Int64
+
+// newFakeORM creates an ORM over the fake driver provided, registered under a name derived from the test. The
+// connection configuration provided, if any, is kept and only pointed at that driver.
+func newFakeORM(t *testing.T, d *fakedriver.Driver, cfg *Configuration) ORM {
+	t.Helper()
+	if cfg == nil {
+		cfg = &Configuration{}
+	}
+	if cfg.PoolConfig == nil {
+		cfg.PoolConfig = &pool.Config{}
+	}
+	name := t.Name() + "#" + strconv.FormatInt(fakeDrivers.Add(1), 10)
+	if cfg.PoolConfig.ConnectionConfig == nil {
+		cfg.PoolConfig.ConnectionConfig = &alphasql.ConnectionConfig{}
+	}
+	cfg.PoolConfig.ConnectionConfig.DriverName = name
+	cfg.PoolConfig.ConnectionConfig.URL = "fake"
+	alphasql.RegisterDriver(name, d)
+	t.Cleanup(func() { alphasql.DeregisterDriver(name) })
+	o, err := New(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+	t.Cleanup(func() { _ = o.Close(context.Background()) })
+	return o
+}
+
+// usersDriver returns a fake driver answering every query with the users provided.
+func usersDriver(users ...user) *fakedriver.Driver {
+	return &fakedriver.Driver{
+		Query: func(_ context.Context, _ *fakedriver.Conn, _ string, _ []driver.NamedValue) (driver.Rows, error) {
+			return usersRows(users...), nil
+		},
+	}
+}
+
+func usersRows(users ...user) *fakedriver.Rows {
+	rows := make([][]driver.Value, len(users))
+	for i, u := range users {
+		rows[i] = []driver.Value{u.ID, u.Name}
+	}
+	return fakedriver.NewRows([]string{"id", "name"}, rows...)
+}
+
+// user is the entity used by the tests, mapped to the users table.
+type user struct {
+	ID   int64  `db:"id"`
+	Name string `db:"name"`
+}
+
+func (u *user) GetIDQuery() string {
+	return "SELECT id, name FROM users WHERE id = $1"
+}
+
+func (u *user) GetIDArgs() []interface{} {
+	return []interface{}{u.ID}
+}
+
+func (u *user) GetAllQuery() string {
+	return "SELECT id, name FROM users"
+}
+
+func (u *user) GetAllQueryArgs() []interface{} {
+	return nil
+}
+
+func (u *user) GetAllPagedQuery(limit, offset int) (string, []interface{}) {
+	if limit == 0 {
+		return "SELECT id, name FROM users OFFSET $1", []interface{}{offset}
+	}
+	return "SELECT id, name FROM users LIMIT $1 OFFSET $2", []interface{}{limit, offset}
+}
+
+func (u *user) GetNext() entity.Entity {
+	return &user{}
+}
+
+func (u *user) BindRow(row entity.Scanner) error {
+	return row.Scan(context.Background(), &u.ID, &u.Name)
+}
+
+func (u *user) GetFreshSaveQuery() string {
+	return "INSERT INTO users (id, name) VALUES ($1, $2)"
+}
+
+func (u *user) GetFreshSaveArgs() []interface{} {
+	return []interface{}{u.ID, u.Name}
+}
+
+func (u *user) GetSaveQuery() string {
+	return "UPDATE users SET name = $2 WHERE id = $1"
+}
+
+func (u *user) GetSaveArgs() []interface{} {
+	return []interface{}{u.ID, u.Name}
+}
+
+func (u *user) GetDeleteQuery() string {
+	return "DELETE FROM users WHERE id = $1"
+}
+
+func (u *user) GetDeleteArgs() []interface{} {
+	return []interface{}{u.ID}
+}
+
+func (u *user) GetDeleteAllQuery() string {
+	return "DELETE FROM users"
+}
+
+func (u *user) GetCountQuery() string {
+	return "SELECT COUNT(*) FROM users"
+}
+
+func (u *user) GetCountArgs() []interface{} {
+	return nil
+}
+
+func (u *user) GetExistsQuery() string {
+	return "SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)"
+}
+
+func (u *user) GetExistsArgs() []interface{} {
+	return []interface{}{u.ID}
+}
+
+// rawUser is the raw entity used by the tests, running the queries and the executions set for each code.
+type rawUser struct {
+	user
+	queries map[int]string
+	args    map[int][]interface{}
+}
+
+func newRawUser(queries map[int]string, args map[int][]interface{}) *rawUser {
+	return &rawUser{queries: queries, args: args}
+}
+
+func (u *rawUser) GetQueryRow(code int) string {
+	return u.queries[code]
+}
+
+func (u *rawUser) GetQueryRowArgs(code int) []interface{} {
+	return u.args[code]
+}
+
+func (u *rawUser) GetQuery(code int) string {
+	return u.queries[code]
+}
+
+func (u *rawUser) GetQueryArgs(code int) []interface{} {
+	return u.args[code]
+}
+
+func (u *rawUser) GetNext() entity.RawEntity {
+	return newRawUser(u.queries, u.args)
+}
+
+func (u *rawUser) BindRow(_ int, row entity.Scanner) error {
+	return u.user.BindRow(row)
+}
+
+func (u *rawUser) GetExec(code int) string {
+	return u.queries[code]
+}
+
+func (u *rawUser) GetExecArgs(code int) []interface{} {
+	return u.args[code]
+}
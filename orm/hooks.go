@@ -0,0 +1,26 @@
+package orm
+
+import (
+	"context"
+	"github.com/sinhashubham95/alpha-sql/orm/entity"
+)
+
+// runHook invokes the hook of the entity if it implements entity.Hookable.
+func runHook(ctx context.Context, e entity.Entity, hook func(entity.Hookable, context.Context) error) error {
+	if h, ok := e.(entity.Hookable); ok {
+		return hook(h, ctx)
+	}
+	return nil
+}
+
+// runHooks invokes the hook of each of the entities implementing entity.Hookable in order, stopping at the first
+// error returned.
+func runHooks(ctx context.Context, es []entity.Entity, hook func(entity.Hookable, context.Context) error) error {
+	for _, e := range es {
+		err := runHook(ctx, e, hook)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -2,29 +2,60 @@ package orm
 
 import (
 	"context"
+	"errors"
 	alphasql "github.com/sinhashubham95/alpha-sql"
 	"github.com/sinhashubham95/alpha-sql/orm/entity"
 	"github.com/sinhashubham95/alpha-sql/pool"
 	"sync/atomic"
+	"time"
 )
 
 // ORM is used to provide the ORM functionalities.
 type ORM interface {
 	// GetByID is used to handle scenarios where the data of an entity has to be fetched by a primary key.
-	GetByID(ctx context.Context, e entity.Entity) error
+	// The entity may be an entity.ViewEntity, mapping a read model such as a view or a join.
+	GetByID(ctx context.Context, e entity.ReadableEntity) error
 
 	// GetAll is used to handle scenarios where all the data of an entity has to be fetched.
 	GetAll(ctx context.Context, e entity.Entity) ([]entity.Entity, error)
 
+	// GetAllPaged is used to fetch a page of the data of an entity, of at most limit entities after skipping offset
+	// of them, with a limit of 0 meaning no limit. It returns alphasql.ErrNoRows only if the first page is empty,
+	// while the pages past the end are returned empty.
+	GetAllPaged(ctx context.Context, e entity.Entity, limit, offset int) ([]entity.Entity, error)
+
+	// GetAllViews is used to fetch all the data of a read-only view entity, like GetAll does for the entities,
+	// which returns them as entity.Entity values a view entity cannot be.
+	GetAllViews(ctx context.Context, e entity.ViewEntity) ([]entity.ViewEntity, error)
+
+	// Count is used to count the rows of an entity, a count of 0 being returned if the query selects no rows.
+	Count(ctx context.Context, e entity.Entity) (int64, error)
+
+	// Exists is used to check whether an entity exists, typically using a SELECT EXISTS(...) query,
+	// without fetching its data. It never returns alphasql.ErrNoRows, absence being reported as false.
+	Exists(ctx context.Context, e entity.Entity) (bool, error)
+
 	// FreshSave is used to freshly save(insert) the provided set of entities.
 	FreshSave(ctx context.Context, es ...entity.Entity) error
 
 	// Save is used ot save(upsert) the provided set of entities.
 	Save(ctx context.Context, es ...entity.Entity) error
 
+	// BatchSave is used to save(upsert) the provided set of entities using a single query, updating the rows
+	// conflicting on the columns provided, at least one of which is required, alphasql.ErrBatchSaveNoConflictColumns
+	// being returned otherwise. The entities must implement entity.BatchSaveEntity.
+	// The query is built in the PostgreSQL dialect, using an INSERT ... ON CONFLICT clause and the numbered
+	// placeholders($1, $2, ...), so it is only supported by the databases and the drivers accepting them.
+	// If all the columns are conflict columns, the conflicting rows are left as they are, and the rows affected
+	// are not checked, as a batch of entities all conflicting is a valid noop.
+	BatchSave(ctx context.Context, conflictColumns []string, es ...entity.Entity) error
+
 	// Delete is used to delete the provided set of entities.
 	Delete(ctx context.Context, es ...entity.Entity) error
 
+	// DeleteAll is used to delete all the data of an entity, returning the number of rows affected.
+	DeleteAll(ctx context.Context, e entity.Entity) (int64, error)
+
 	// QueryRow is used to perform the query for the code specified.
 	QueryRow(ctx context.Context, e entity.RawEntity, code int) error
 
@@ -34,9 +65,20 @@ type ORM interface {
 	// Exec is used to execute all the executions as per the entity and the code specified.
 	Exec(ctx context.Context, es ...entity.RawExec) error
 
+	// WithSession is used to pin a single connection for the reads made with the context passed to fn, such as
+	// several GetByID calls handling a request, reducing the acquisitions from the pool without starting a
+	// transaction. The connection is released once fn returns.
+	WithSession(ctx context.Context, fn func(ctx context.Context) error) error
+
 	// BeginTX is used to start a new transaction on ORM.
 	BeginTX(ctx context.Context, options *alphasql.TXOptions) (TransactionalORM, error)
 
+	// TransactionFunc is used to run fn inside a transaction, committing it if fn succeeds and rolling it back
+	// otherwise. If the transaction fails with an error reported retryable by Configuration.IsRetryable, such as
+	// a serialization failure, it is retried up to Configuration.TransactionMaxAttempts times in total, backing
+	// off between the attempts, and stopping once the context is done.
+	TransactionFunc(ctx context.Context, options *alphasql.TXOptions, fn func(TransactionalORM) error) error
+
 	// Close is used to close the ORM.
 	Close(ctx context.Context) error
 }
@@ -44,20 +86,49 @@ type ORM interface {
 // TransactionalORM is used to provide the ORM functionalities around an alphasql.TX.
 type TransactionalORM interface {
 	// GetByID is used to handle scenarios where the data of an entity has to be fetched by a primary key.
-	GetByID(ctx context.Context, e entity.Entity) error
+	// The entity may be an entity.ViewEntity, mapping a read model such as a view or a join.
+	GetByID(ctx context.Context, e entity.ReadableEntity) error
 
 	// GetAll is used to handle scenarios where all the data of an entity has to be fetched.
 	GetAll(ctx context.Context, e entity.Entity) ([]entity.Entity, error)
 
+	// GetAllPaged is used to fetch a page of the data of an entity, of at most limit entities after skipping offset
+	// of them, with a limit of 0 meaning no limit. It returns alphasql.ErrNoRows only if the first page is empty,
+	// while the pages past the end are returned empty.
+	GetAllPaged(ctx context.Context, e entity.Entity, limit, offset int) ([]entity.Entity, error)
+
+	// GetAllViews is used to fetch all the data of a read-only view entity, like GetAll does for the entities,
+	// which returns them as entity.Entity values a view entity cannot be.
+	GetAllViews(ctx context.Context, e entity.ViewEntity) ([]entity.ViewEntity, error)
+
+	// Count is used to count the rows of an entity, a count of 0 being returned if the query selects no rows.
+	Count(ctx context.Context, e entity.Entity) (int64, error)
+
+	// Exists is used to check whether an entity exists, typically using a SELECT EXISTS(...) query,
+	// without fetching its data. It never returns alphasql.ErrNoRows, absence being reported as false.
+	Exists(ctx context.Context, e entity.Entity) (bool, error)
+
 	// FreshSave is used to freshly save(insert) the provided set of entities.
 	FreshSave(ctx context.Context, es ...entity.Entity) error
 
 	// Save is used ot save(upsert) the provided set of entities.
 	Save(ctx context.Context, es ...entity.Entity) error
 
+	// BatchSave is used to save(upsert) the provided set of entities using a single query, updating the rows
+	// conflicting on the columns provided, at least one of which is required, alphasql.ErrBatchSaveNoConflictColumns
+	// being returned otherwise. The entities must implement entity.BatchSaveEntity.
+	// The query is built in the PostgreSQL dialect, using an INSERT ... ON CONFLICT clause and the numbered
+	// placeholders($1, $2, ...), so it is only supported by the databases and the drivers accepting them.
+	// If all the columns are conflict columns, the conflicting rows are left as they are, and the rows affected
+	// are not checked, as a batch of entities all conflicting is a valid noop.
+	BatchSave(ctx context.Context, conflictColumns []string, es ...entity.Entity) error
+
 	// Delete is used to delete the provided set of entities.
 	Delete(ctx context.Context, es ...entity.Entity) error
 
+	// DeleteAll is used to delete all the data of an entity, returning the number of rows affected.
+	DeleteAll(ctx context.Context, e entity.Entity) (int64, error)
+
 	// QueryRow is used to perform the query for the code specified.
 	QueryRow(ctx context.Context, e entity.RawEntity, code int) error
 
@@ -79,8 +150,48 @@ type Configuration struct {
 	PoolConfig               *pool.Config
 	IsScanToStructureEnabled bool
 	FailOnNoRowsAffected     bool
+
+	// TruncateOnDeleteAll makes DeleteAll truncate the table for the entities implementing entity.TruncatableEntity,
+	// instead of deleting all the rows.
+	TruncateOnDeleteAll bool
+
+	// OnRowsFetched is called after GetAll or Query with the name of the entity type and the number of rows
+	// materialized, helping detect unexpectedly large reads.
+	OnRowsFetched func(entityName string, count int)
+
+	// EmptyResultAsError makes GetAll, Query and the other operations fetching several entities return
+	// alphasql.ErrNoRows when no rows are selected. If it is set to false, an empty slice is returned instead.
+	// It defaults to true.
+	EmptyResultAsError *bool
+
+	// TransactionMaxAttempts is the maximum number of times TransactionFunc attempts a transaction,
+	// including the first attempt. It defaults to 3.
+	TransactionMaxAttempts int
+
+	// TransactionRetryDelay is the delay before the first retry of a transaction by TransactionFunc,
+	// doubled on every following retry. It defaults to 10ms.
+	TransactionRetryDelay time.Duration
+
+	// IsRetryable reports whether a transaction failing with the error should be retried by TransactionFunc.
+	// It is called with the errors of beginning the transaction, of fn and of committing the transaction, all of
+	// which are mapped through alphasql.ConnectionConfig.ErrorMap. It defaults to matching alphasql.ErrDeadlock,
+	// which the drivers errors can be mapped to through that error map.
+	IsRetryable func(err error) bool
 }
 
+// default functions for orm configs.
+var (
+	defaultOnRowsFetched = func(_ string, _ int) {}
+	defaultIsRetryable   = func(err error) bool { return errors.Is(err, alphasql.ErrDeadlock) }
+)
+
+// default values for orm configs.
+var (
+	defaultEmptyResultAsError     = true
+	defaultTransactionMaxAttempts = 3
+	defaultTransactionRetryDelay  = 10 * time.Millisecond
+)
+
 // orm is used to provide a wrapper around the orm functionalities.
 type orm struct {
 	p *pool.Pool
@@ -88,6 +199,10 @@ type orm struct {
 	cfg                      *Configuration
 	isScanToStructureEnabled bool
 	failOnNoRowsAffected     bool
+	truncateOnDeleteAll      bool
+	onRowsFetched            func(entityName string, count int)
+	emptyResultAsError       bool
+	transactionRetry         alphasql.RetryPolicy
 
 	closed atomic.Bool
 }
@@ -113,6 +228,15 @@ func New(ctx context.Context, cfg *Configuration) (ORM, error) {
 		cfg:                      cfg,
 		isScanToStructureEnabled: cfg.IsScanToStructureEnabled,
 		failOnNoRowsAffected:     cfg.FailOnNoRowsAffected,
+		truncateOnDeleteAll:      cfg.TruncateOnDeleteAll,
+		onRowsFetched:            cfg.OnRowsFetched,
+		emptyResultAsError:       *cfg.EmptyResultAsError,
+		transactionRetry: alphasql.RetryPolicy{
+			MaxAttempts: cfg.TransactionMaxAttempts,
+			BaseDelay:   cfg.TransactionRetryDelay,
+			Jitter:      cfg.TransactionRetryDelay,
+			Retryable:   cfg.IsRetryable,
+		},
 	}, nil
 }
 
@@ -128,10 +252,27 @@ func (o *orm) BeginTX(ctx context.Context, options *alphasql.TXOptions) (Transac
 	}, nil
 }
 
+// TransactionFunc is used to run fn inside a transaction, retrying it when it fails with a retryable error.
+func (o *orm) TransactionFunc(ctx context.Context, options *alphasql.TXOptions, fn func(TransactionalORM) error) error {
+	return o.transactionRetry.Do(ctx, func(ctx context.Context) error {
+		t, err := o.BeginTX(ctx, options)
+		if err != nil {
+			return err
+		}
+		err = fn(t)
+		if err != nil {
+			_ = t.Rollback(ctx)
+			return err
+		}
+		return t.Commit(ctx)
+	})
+}
+
 // Close is used to close the orm.
 func (o *orm) Close(ctx context.Context) error {
 	if o.closed.CompareAndSwap(false, true) {
 		o.p.Close(ctx)
+		return nil
 	}
 	return alphasql.ErrORMClosed
 }
@@ -140,5 +281,21 @@ func (c *Configuration) validate() error {
 	if c.PoolConfig == nil {
 		return alphasql.ErrMissingPoolConfig
 	}
+	if c.OnRowsFetched == nil {
+		c.OnRowsFetched = defaultOnRowsFetched
+	}
+	if c.TransactionMaxAttempts == 0 {
+		c.TransactionMaxAttempts = defaultTransactionMaxAttempts
+	}
+	if c.TransactionRetryDelay == 0 {
+		c.TransactionRetryDelay = defaultTransactionRetryDelay
+	}
+	if c.IsRetryable == nil {
+		c.IsRetryable = defaultIsRetryable
+	}
+	if c.EmptyResultAsError == nil {
+		emptyResultAsError := defaultEmptyResultAsError
+		c.EmptyResultAsError = &emptyResultAsError
+	}
 	return nil
 }
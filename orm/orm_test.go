@@ -0,0 +1,28 @@
+package orm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	alphasql "github.com/sinhashubham95/alpha-sql"
+)
+
+func TestClose(t *testing.T) {
+	d := usersDriver(user{ID: 1, Name: "a"})
+	o := newFakeORM(t, d, nil)
+	ctx := context.Background()
+	if _, err := o.GetAll(ctx, &user{}); err != nil {
+		t.Fatalf("get all: %v", err)
+	}
+
+	if err := o.Close(ctx); err != nil {
+		t.Fatalf("got %v closing the first time, want nil", err)
+	}
+	if closes, connects := d.Closes.Load(), d.Connects.Load(); closes != connects {
+		t.Fatalf("got %d of %d connections closed, want the pool closed", closes, connects)
+	}
+	if err := o.Close(ctx); !errors.Is(err, alphasql.ErrORMClosed) {
+		t.Fatalf("got %v closing the second time, want %v", err, alphasql.ErrORMClosed)
+	}
+}
@@ -0,0 +1,20 @@
+package orm
+
+import (
+	"context"
+	"github.com/sinhashubham95/alpha-sql/orm/entity"
+)
+
+func (o *orm) GetAllPaged(ctx context.Context, e entity.Entity, limit, offset int) ([]entity.Entity, error) {
+	return o.getAllPaged(ctx, o.querier(ctx), e, limit, offset)
+}
+
+func (t *transactionalORM) GetAllPaged(ctx context.Context, e entity.Entity, limit, offset int) ([]entity.Entity, error) {
+	return t.o.getAllPaged(ctx, t.tx, e, limit, offset)
+}
+
+func (o *orm) getAllPaged(ctx context.Context, q querier, e entity.Entity, limit, offset int) ([]entity.Entity, error) {
+	query, args := e.GetAllPagedQuery(limit, offset)
+	// only an empty first page is an empty result, the pages past the end being returned empty
+	return fetchAll(ctx, o, q, e, entity.Entity.GetNext, query, args, offset == 0 && o.emptyResultAsError)
+}
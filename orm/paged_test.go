@@ -0,0 +1,83 @@
+package orm
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+
+	alphasql "github.com/sinhashubham95/alpha-sql"
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+)
+
+// pagedDriver returns a fake driver answering the paged queries of the users with the page of the users provided.
+func pagedDriver(users ...user) *fakedriver.Driver {
+	return &fakedriver.Driver{
+		Query: func(_ context.Context, _ *fakedriver.Conn, query string, args []driver.NamedValue) (driver.Rows, error) {
+			limit, offset := int64(len(users)), args[0].Value.(int64)
+			if strings.Contains(query, "LIMIT") {
+				limit, offset = args[0].Value.(int64), args[1].Value.(int64)
+			}
+			page := users[min64(offset, int64(len(users))):min64(offset+limit, int64(len(users)))]
+			return usersRows(page...), nil
+		},
+	}
+}
+
+func min64(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func TestGetAllPaged(t *testing.T) {
+	users := []user{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}, {ID: 3, Name: "c"}, {ID: 4, Name: "d"}, {ID: 5, Name: "e"}}
+	o := newFakeORM(t, pagedDriver(users...), nil)
+	for _, tc := range []struct {
+		name          string
+		limit, offset int
+		want          []int64
+	}{
+		{"first page", 2, 0, []int64{1, 2}},
+		{"middle page", 2, 2, []int64{3, 4}},
+		{"last page", 2, 4, []int64{5}},
+		{"past the end", 2, 6, []int64{}},
+		{"no limit", 0, 0, []int64{1, 2, 3, 4, 5}},
+		{"no limit with offset", 0, 3, []int64{4, 5}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			es, err := o.GetAllPaged(context.Background(), &user{}, tc.limit, tc.offset)
+			if err != nil {
+				t.Fatalf("get all paged: %v", err)
+			}
+			if es == nil {
+				t.Fatal("got a nil page, want an empty one")
+			}
+			got := make([]int64, len(es))
+			for i, e := range es {
+				got[i] = e.(*user).ID
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("got ids %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Fatalf("got ids %v, want %v", got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestGetAllPagedEmptyTable(t *testing.T) {
+	o := newFakeORM(t, pagedDriver(), nil)
+	if _, err := o.GetAllPaged(context.Background(), &user{}, 10, 0); !errors.Is(err, alphasql.ErrNoRows) {
+		t.Fatalf("got %v for the first page, want %v", err, alphasql.ErrNoRows)
+	}
+	es, err := o.GetAllPaged(context.Background(), &user{}, 10, 10)
+	if err != nil || len(es) != 0 {
+		t.Fatalf("got %v, %v for a later page, want no entities and no error", es, err)
+	}
+}
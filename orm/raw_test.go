@@ -0,0 +1,158 @@
+package orm
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"sync"
+	"testing"
+
+	alphasql "github.com/sinhashubham95/alpha-sql"
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+	"github.com/sinhashubham95/alpha-sql/orm/entity"
+)
+
+// argsRecorder records the args of the queries and the executions received by a fake driver.
+type argsRecorder struct {
+	mu   sync.Mutex
+	args map[string][]driver.NamedValue
+}
+
+func (r *argsRecorder) record(query string, args []driver.NamedValue) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.args == nil {
+		r.args = make(map[string][]driver.NamedValue)
+	}
+	r.args[query] = args
+}
+
+func (r *argsRecorder) get(query string) []driver.NamedValue {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.args[query]
+}
+
+func (r *argsRecorder) driver(users ...user) *fakedriver.Driver {
+	return &fakedriver.Driver{
+		Query: func(_ context.Context, _ *fakedriver.Conn, query string, args []driver.NamedValue) (driver.Rows, error) {
+			r.record(query, args)
+			return usersRows(users...), nil
+		},
+		Exec: func(_ context.Context, _ *fakedriver.Conn, query string, args []driver.NamedValue) (driver.Result, error) {
+			r.record(query, args)
+			return driver.RowsAffected(1), nil
+		},
+	}
+}
+
+func assertNamedValues(t *testing.T, got []driver.NamedValue, want ...driver.NamedValue) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d args %v, want %v", len(got), got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got arg %d %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestRawEntityNamedArgs(t *testing.T) {
+	const (
+		queryRowCode = iota + 1
+		queryCode
+		execCode
+	)
+	var r argsRecorder
+	o := newFakeORM(t, r.driver(user{ID: 1, Name: "a"}), nil)
+	e := newRawUser(map[int]string{
+		queryRowCode: "SELECT id, name FROM users WHERE id = @id",
+		queryCode:    "SELECT id, name FROM users WHERE name = @name AND id > @id",
+		execCode:     "UPDATE users SET name = @name WHERE id = @id",
+	}, map[int][]interface{}{
+		queryRowCode: {alphasql.Named("id", int64(1))},
+		queryCode:    {alphasql.Named("name", "a"), &alphasql.NamedArg{Name: "id", Value: int64(0)}},
+		execCode:     {int64(1), alphasql.Named("name", "b")},
+	})
+	ctx := context.Background()
+
+	if err := o.QueryRow(ctx, e, queryRowCode); err != nil {
+		t.Fatalf("query row: %v", err)
+	}
+	if e.ID != 1 || e.Name != "a" {
+		t.Fatalf("got %+v, want the user bound", e.user)
+	}
+	assertNamedValues(t, r.get(e.queries[queryRowCode]), driver.NamedValue{Name: "id", Ordinal: 1, Value: int64(1)})
+
+	if _, err := o.Query(ctx, e, queryCode); err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	assertNamedValues(t, r.get(e.queries[queryCode]),
+		driver.NamedValue{Name: "name", Ordinal: 1, Value: "a"},
+		driver.NamedValue{Name: "id", Ordinal: 2, Value: int64(0)})
+
+	if err := o.Exec(ctx, entity.RawExec{Entity: e, Code: execCode}); err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+	assertNamedValues(t, r.get(e.queries[execCode]),
+		driver.NamedValue{Ordinal: 1, Value: int64(1)},
+		driver.NamedValue{Name: "name", Ordinal: 2, Value: "b"})
+}
+
+func TestRawEntityInvalidNamedArg(t *testing.T) {
+	var r argsRecorder
+	o := newFakeORM(t, r.driver(), nil)
+	e := newRawUser(map[int]string{1: "SELECT id, name FROM users WHERE id = @1id"},
+		map[int][]interface{}{1: {alphasql.Named("1id", int64(1))}})
+
+	if _, err := o.Query(context.Background(), e, 1); err == nil {
+		t.Fatal("got no error, want the invalid name of the named arg rejected")
+	}
+	if args := r.get(e.queries[1]); args != nil {
+		t.Fatalf("got the query run with %v, want it rejected before reaching the driver", args)
+	}
+}
+
+func TestRawExecStructureArgs(t *testing.T) {
+	type rename struct {
+		ID   int64  `db:"id"`
+		Name string `db:"name"`
+	}
+	const query = "UPDATE users SET name = @name WHERE id = @id"
+	var r argsRecorder
+	o := newFakeORM(t, r.driver(), nil)
+	// the args of the entity are ignored once the args of the execution are set
+	e := newRawUser(map[int]string{1: query}, map[int][]interface{}{1: {int64(9), "ignored"}})
+	ctx := context.Background()
+	want := []driver.NamedValue{{Name: "id", Ordinal: 1, Value: int64(1)}, {Name: "name", Ordinal: 2, Value: "b"}}
+
+	for _, args := range []interface{}{rename{ID: 1, Name: "b"}, &rename{ID: 1, Name: "b"}} {
+		if err := o.Exec(ctx, entity.RawExec{Entity: e, Code: 1, Args: args}); err != nil {
+			t.Fatalf("exec with %T: %v", args, err)
+		}
+		assertNamedValues(t, r.get(query), want...)
+	}
+
+	err := o.TransactionFunc(ctx, nil, func(tx TransactionalORM) error {
+		return tx.Exec(ctx, entity.RawExec{Entity: e, Code: 1, Args: &rename{ID: 1, Name: "b"}})
+	})
+	if err != nil {
+		t.Fatalf("exec in a transaction: %v", err)
+	}
+	assertNamedValues(t, r.get(query), want...)
+}
+
+func TestRawExecStructureArgsNotAStructure(t *testing.T) {
+	var r argsRecorder
+	o := newFakeORM(t, r.driver(), nil)
+	e := newRawUser(map[int]string{1: "UPDATE users SET name = @name WHERE id = @id"}, nil)
+
+	err := o.Exec(context.Background(), entity.RawExec{Entity: e, Code: 1, Args: []int64{1}})
+	if !errors.Is(err, alphasql.ErrNotAStructure) {
+		t.Fatalf("got %v, want %v", err, alphasql.ErrNotAStructure)
+	}
+	if args := r.get(e.queries[1]); args != nil {
+		t.Fatalf("got the execution run with %v, want it rejected before reaching the driver", args)
+	}
+}
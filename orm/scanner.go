@@ -10,6 +10,7 @@ type scan func(ctx context.Context, values ...any) error
 type scannerRow struct {
 	r                        alphasql.Row
 	isScanToStructureEnabled bool
+	scanned                  bool
 }
 
 type scannerRows struct {
@@ -18,22 +19,37 @@ type scannerRows struct {
 }
 
 func (s *scannerRow) Scan(ctx context.Context, values ...any) error {
+	s.scanned = true
 	return s.r.Scan(ctx, values...)
 }
 
 func (s *scannerRow) ScanStructure(ctx context.Context, value interface{}) error {
-	if s.isScanToStructureEnabled {
+	if !s.isScanToStructureEnabled {
 		return alphasql.ErrScanToStructureNotEnabled
 	}
+	s.scanned = true
 	return scanToStructure(ctx, s.r.Scan, s.r.Columns(), value)
 }
 
+// discard scans the row into throwaway values when the entity returned without scanning it, as the row is only
+// closed once scanned, releasing its connection back to the pool.
+func (s *scannerRow) discard(ctx context.Context) {
+	if s.scanned {
+		return
+	}
+	values := make([]any, len(s.r.Columns()))
+	for i := range values {
+		values[i] = new(any)
+	}
+	_ = s.r.Scan(ctx, values...)
+}
+
 func (s *scannerRows) Scan(_ context.Context, values ...any) error {
 	return s.r.Scan(values...)
 }
 
 func (s *scannerRows) ScanStructure(ctx context.Context, value interface{}) error {
-	if s.isScanToStructureEnabled {
+	if !s.isScanToStructureEnabled {
 		return alphasql.ErrScanToStructureNotEnabled
 	}
 	return scanToStructure(ctx, getScan(s.r), s.r.Columns(), value)
@@ -45,6 +61,8 @@ func getScan(r alphasql.Rows) scan {
 	}
 }
 
-func scanToStructure(_ context.Context, _ scan, _ []alphasql.Column, _ interface{}) error {
-	return nil
+func scanToStructure(ctx context.Context, s scan, columns []alphasql.Column, value interface{}) error {
+	return alphasql.ScanStructure(func(values ...any) error {
+		return s(ctx, values...)
+	}, columns, value)
 }
@@ -0,0 +1,65 @@
+package orm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	alphasql "github.com/sinhashubham95/alpha-sql"
+	"github.com/sinhashubham95/alpha-sql/orm/entity"
+)
+
+// structureUser is a user bound by scanning the rows into its structure.
+type structureUser struct {
+	user
+}
+
+func (u *structureUser) GetNext() entity.Entity {
+	return &structureUser{}
+}
+
+func (u *structureUser) BindRow(row entity.Scanner) error {
+	return row.ScanStructure(context.Background(), &u.user)
+}
+
+func TestScanStructure(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		enabled bool
+		err     error
+	}{
+		{"enabled", true, nil},
+		{"disabled", false, alphasql.ErrScanToStructureNotEnabled},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			o := newFakeORM(t, usersDriver(user{ID: 1, Name: "a"}, user{ID: 2, Name: "b"}),
+				&Configuration{IsScanToStructureEnabled: tc.enabled})
+			ctx := context.Background()
+
+			// the row left unscanned when disabled must still release its connection, or closing the ORM would block
+			t.Run("row", func(t *testing.T) {
+				u := &structureUser{user{ID: 1}}
+				err := o.GetByID(ctx, u)
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("got %v, want %v", err, tc.err)
+				}
+				if tc.err == nil && u.Name != "a" {
+					t.Fatalf("got %+v, want the user scanned", u.user)
+				}
+			})
+
+			t.Run("rows", func(t *testing.T) {
+				us, err := o.GetAll(ctx, &structureUser{})
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("got %v, want %v", err, tc.err)
+				}
+				if tc.err != nil {
+					return
+				}
+				if len(us) != 2 || us[0].(*structureUser).Name != "a" || us[1].(*structureUser).ID != 2 {
+					t.Fatalf("got %v, want both users scanned", us)
+				}
+			})
+		})
+	}
+}
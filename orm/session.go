@@ -0,0 +1,34 @@
+package orm
+
+import (
+	"context"
+	"github.com/sinhashubham95/alpha-sql/pool"
+)
+
+type sessionKey struct{}
+
+// session holds the Connection pinned for the reads made with a context by WithSession.
+type session struct {
+	o *orm
+	c *pool.Connection
+}
+
+func (o *orm) WithSession(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s, ok := ctx.Value(sessionKey{}).(*session); ok && s.o == o {
+		return fn(ctx)
+	}
+	c, err := o.p.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer o.p.Release(ctx, c)
+	return fn(context.WithValue(ctx, sessionKey{}, &session{o: o, c: c}))
+}
+
+// querier returns the Connection pinned by the session the context carries, if any, or the pool otherwise.
+func (o *orm) querier(ctx context.Context) querier {
+	if s, ok := ctx.Value(sessionKey{}).(*session); ok && s.o == o {
+		return s.c
+	}
+	return o.p
+}
@@ -0,0 +1,73 @@
+package orm
+
+import (
+	"context"
+	"database/sql/driver"
+	"sync"
+	"testing"
+
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+)
+
+// connsDriver returns a fake driver answering every query with a user, recording the connection each query ran on.
+func connsDriver(mu *sync.Mutex, ids *[]int64) *fakedriver.Driver {
+	return &fakedriver.Driver{
+		Query: func(_ context.Context, c *fakedriver.Conn, _ string, _ []driver.NamedValue) (driver.Rows, error) {
+			mu.Lock()
+			*ids = append(*ids, c.ID)
+			mu.Unlock()
+			return usersRows(user{ID: 1, Name: "a"}), nil
+		},
+	}
+}
+
+func TestWithSessionSharesConnection(t *testing.T) {
+	var mu sync.Mutex
+	var ids []int64
+	o := newFakeORM(t, connsDriver(&mu, &ids), nil)
+	ctx := context.Background()
+
+	err := o.WithSession(ctx, func(ctx context.Context) error {
+		for i := 0; i < 3; i++ {
+			if err := o.GetByID(ctx, &user{ID: 1}); err != nil {
+				return err
+			}
+		}
+		// a nested session keeps using the connection already pinned
+		return o.WithSession(ctx, func(ctx context.Context) error {
+			return o.GetByID(ctx, &user{ID: 1})
+		})
+	})
+	if err != nil {
+		t.Fatalf("with session: %v", err)
+	}
+	if len(ids) != 4 {
+		t.Fatalf("got %d queries, want 4", len(ids))
+	}
+	for _, id := range ids {
+		if id != ids[0] {
+			t.Fatalf("got the queries on connections %v, want a single connection", ids)
+		}
+	}
+}
+
+func TestWithSessionOnlyPinsItsContext(t *testing.T) {
+	var mu sync.Mutex
+	var ids []int64
+	o := newFakeORM(t, connsDriver(&mu, &ids), nil)
+	ctx := context.Background()
+
+	err := o.WithSession(ctx, func(sessionCtx context.Context) error {
+		if err := o.GetByID(sessionCtx, &user{ID: 1}); err != nil {
+			return err
+		}
+		// the pinned connection stays acquired, so a read outside the session needs another one
+		return o.GetByID(ctx, &user{ID: 1})
+	})
+	if err != nil {
+		t.Fatalf("with session: %v", err)
+	}
+	if len(ids) != 2 || ids[0] == ids[1] {
+		t.Fatalf("got the queries on connections %v, want two distinct connections", ids)
+	}
+}
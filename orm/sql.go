@@ -4,44 +4,45 @@ import (
 	"context"
 	alphasql "github.com/sinhashubham95/alpha-sql"
 	"github.com/sinhashubham95/alpha-sql/orm/entity"
+	"reflect"
 )
 
-func (o *orm) GetByID(ctx context.Context, e entity.Entity) error {
-	r := o.p.QueryRow(ctx, e.GetIDQuery(), e.GetIDArgs()...)
-	if r.Error() != nil {
-		return r.Error()
-	}
-	return e.BindRow(&scannerRow{r: r, isScanToStructureEnabled: o.isScanToStructureEnabled})
+func (o *orm) GetByID(ctx context.Context, e entity.ReadableEntity) error {
+	return o.getByID(ctx, o.querier(ctx), e)
 }
 
 func (o *orm) GetAll(ctx context.Context, e entity.Entity) ([]entity.Entity, error) {
-	r, err := o.p.Query(ctx, e.GetAllQuery(), e.GetAllQueryArgs()...)
-	if err != nil {
-		return nil, err
-	}
-	defer closeRows(ctx, r)
-	result := make([]entity.Entity, 0)
-	for r.Next(ctx) {
-		err = e.BindRow(&scannerRows{r: r, isScanToStructureEnabled: o.isScanToStructureEnabled})
-		if err != nil {
-			return nil, err
-		}
-		result = append(result, e)
-		e = e.GetNext()
-	}
-	if len(result) == 0 {
-		return nil, alphasql.ErrNoRows
-	}
-	return result, nil
+	return fetchAll(ctx, o, o.querier(ctx), e, entity.Entity.GetNext, e.GetAllQuery(), e.GetAllQueryArgs(),
+		o.emptyResultAsError)
 }
 
 func (o *orm) FreshSave(ctx context.Context, es ...entity.Entity) error {
+	if isMultiFreshSave(es) {
+		err := runHooks(ctx, es, entity.Hookable.BeforeSave)
+		if err != nil {
+			return err
+		}
+		query, args := getMultiFreshSaveQuery(es)
+		r, err := o.p.Exec(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		err = o.checkAllRowsAffected(r, len(es))
+		if err != nil {
+			return err
+		}
+		return runHooks(ctx, es, entity.Hookable.AfterSave)
+	}
 	tx, err := o.p.BeginTX(ctx, nil)
 	if err != nil {
 		return err
 	}
 	defer rollbackTX(ctx, tx)
 	for _, e := range es {
+		err = runHook(ctx, e, entity.Hookable.BeforeSave)
+		if err != nil {
+			return err
+		}
 		r, err := tx.Exec(ctx, e.GetFreshSaveQuery(), e.GetFreshSaveArgs()...)
 		if err != nil {
 			return err
@@ -56,7 +57,11 @@ func (o *orm) FreshSave(ctx context.Context, es ...entity.Entity) error {
 			}
 		}
 	}
-	return tx.Commit(ctx)
+	err = tx.Commit(ctx)
+	if err != nil {
+		return err
+	}
+	return runHooks(ctx, es, entity.Hookable.AfterSave)
 }
 
 func (o *orm) Save(ctx context.Context, es ...entity.Entity) error {
@@ -66,21 +71,20 @@ func (o *orm) Save(ctx context.Context, es ...entity.Entity) error {
 	}
 	defer rollbackTX(ctx, tx)
 	for _, e := range es {
-		r, err := tx.Exec(ctx, e.GetSaveQuery(), e.GetSaveArgs()...)
+		err = runHook(ctx, e, entity.Hookable.BeforeSave)
 		if err != nil {
 			return err
 		}
-		if o.failOnNoRowsAffected {
-			rows, err := r.RowsAffected()
-			if err != nil {
-				return err
-			}
-			if rows == 0 {
-				return alphasql.ErrNoRowsAffected
-			}
+		err = o.save(ctx, tx, e)
+		if err != nil {
+			return err
 		}
 	}
-	return tx.Commit(ctx)
+	err = tx.Commit(ctx)
+	if err != nil {
+		return err
+	}
+	return runHooks(ctx, es, entity.Hookable.AfterSave)
 }
 
 func (o *orm) Delete(ctx context.Context, es ...entity.Entity) error {
@@ -90,6 +94,10 @@ func (o *orm) Delete(ctx context.Context, es ...entity.Entity) error {
 	}
 	defer rollbackTX(ctx, tx)
 	for _, e := range es {
+		err = runHook(ctx, e, entity.Hookable.BeforeDelete)
+		if err != nil {
+			return err
+		}
 		r, err := tx.Exec(ctx, e.GetDeleteQuery(), e.GetDeleteArgs()...)
 		if err != nil {
 			return err
@@ -104,23 +112,51 @@ func (o *orm) Delete(ctx context.Context, es ...entity.Entity) error {
 			}
 		}
 	}
-	return tx.Commit(ctx)
+	err = tx.Commit(ctx)
+	if err != nil {
+		return err
+	}
+	return runHooks(ctx, es, entity.Hookable.AfterDelete)
+}
+
+func (o *orm) DeleteAll(ctx context.Context, e entity.Entity) (int64, error) {
+	tx, err := o.p.BeginTX(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer rollbackTX(ctx, tx)
+	r, err := tx.Exec(ctx, o.getDeleteAllQuery(e))
+	if err != nil {
+		return 0, err
+	}
+	rows, err := r.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return rows, nil
 }
 
 func (o *orm) QueryRow(ctx context.Context, e entity.RawEntity, code int) error {
-	r := o.p.QueryRow(ctx, e.GetQueryRow(code), e.GetQueryRowArgs(code)...)
+	r := o.querier(ctx).QueryRow(ctx, e.GetQueryRow(code), e.GetQueryRowArgs(code)...)
 	if r.Error() != nil {
 		return r.Error()
 	}
-	return e.BindRow(code, &scannerRow{r: r, isScanToStructureEnabled: o.isScanToStructureEnabled})
+	s := &scannerRow{r: r, isScanToStructureEnabled: o.isScanToStructureEnabled}
+	defer s.discard(ctx)
+	return e.BindRow(code, s)
 }
 
 func (o *orm) Query(ctx context.Context, e entity.RawEntity, code int) ([]entity.RawEntity, error) {
-	r, err := o.p.Query(ctx, e.GetQuery(code), e.GetQueryArgs(code)...)
+	r, err := o.querier(ctx).Query(ctx, e.GetQuery(code), e.GetQueryArgs(code)...)
 	if err != nil {
 		return nil, err
 	}
 	defer closeRows(ctx, r)
+	name := getEntityName(e)
 	result := make([]entity.RawEntity, 0)
 	for r.Next(ctx) {
 		err = e.BindRow(code, &scannerRows{r: r, isScanToStructureEnabled: o.isScanToStructureEnabled})
@@ -130,7 +166,11 @@ func (o *orm) Query(ctx context.Context, e entity.RawEntity, code int) ([]entity
 		result = append(result, e)
 		e = e.GetNext()
 	}
-	if len(result) == 0 {
+	if err = r.Error(); err != nil {
+		return nil, err
+	}
+	o.onRowsFetched(name, len(result))
+	if len(result) == 0 && o.emptyResultAsError {
 		return nil, alphasql.ErrNoRows
 	}
 	return result, nil
@@ -143,7 +183,11 @@ func (o *orm) Exec(ctx context.Context, es ...entity.RawExec) error {
 	}
 	defer rollbackTX(ctx, tx)
 	for _, e := range es {
-		r, err := tx.Exec(ctx, e.Entity.GetExec(e.Code), e.Entity.GetExecArgs(e.Code)...)
+		args, err := getRawExecArgs(e)
+		if err != nil {
+			return err
+		}
+		r, err := tx.Exec(ctx, e.Entity.GetExec(e.Code), args...)
 		if err != nil {
 			return err
 		}
@@ -160,37 +204,37 @@ func (o *orm) Exec(ctx context.Context, es ...entity.RawExec) error {
 	return tx.Commit(ctx)
 }
 
-func (t *transactionalORM) GetByID(ctx context.Context, e entity.Entity) error {
-	r := t.tx.QueryRow(ctx, e.GetIDQuery(), e.GetIDArgs()...)
-	if r.Error() != nil {
-		return r.Error()
-	}
-	return e.BindRow(&scannerRow{r: r, isScanToStructureEnabled: t.o.isScanToStructureEnabled})
+func (t *transactionalORM) GetByID(ctx context.Context, e entity.ReadableEntity) error {
+	return t.o.getByID(ctx, t.tx, e)
 }
 
 func (t *transactionalORM) GetAll(ctx context.Context, e entity.Entity) ([]entity.Entity, error) {
-	r, err := t.tx.Query(ctx, e.GetAllQuery(), e.GetAllQueryArgs()...)
-	if err != nil {
-		return nil, err
-	}
-	defer closeRows(ctx, r)
-	result := make([]entity.Entity, 0)
-	for r.Next(ctx) {
-		err = e.BindRow(&scannerRows{r: r, isScanToStructureEnabled: t.o.isScanToStructureEnabled})
-		if err != nil {
-			return nil, err
-		}
-		result = append(result, e)
-		e = e.GetNext()
-	}
-	if len(result) == 0 {
-		return nil, alphasql.ErrNoRows
-	}
-	return result, nil
+	return fetchAll(ctx, t.o, t.tx, e, entity.Entity.GetNext, e.GetAllQuery(), e.GetAllQueryArgs(),
+		t.o.emptyResultAsError)
 }
 
 func (t *transactionalORM) FreshSave(ctx context.Context, es ...entity.Entity) error {
+	if isMultiFreshSave(es) {
+		err := runHooks(ctx, es, entity.Hookable.BeforeSave)
+		if err != nil {
+			return err
+		}
+		query, args := getMultiFreshSaveQuery(es)
+		r, err := t.tx.Exec(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		err = t.o.checkAllRowsAffected(r, len(es))
+		if err != nil {
+			return err
+		}
+		return runHooks(ctx, es, entity.Hookable.AfterSave)
+	}
 	for _, e := range es {
+		err := runHook(ctx, e, entity.Hookable.BeforeSave)
+		if err != nil {
+			return err
+		}
 		r, err := t.tx.Exec(ctx, e.GetFreshSaveQuery(), e.GetFreshSaveArgs()...)
 		if err != nil {
 			return err
@@ -205,30 +249,29 @@ func (t *transactionalORM) FreshSave(ctx context.Context, es ...entity.Entity) e
 			}
 		}
 	}
-	return nil
+	return runHooks(ctx, es, entity.Hookable.AfterSave)
 }
 
 func (t *transactionalORM) Save(ctx context.Context, es ...entity.Entity) error {
 	for _, e := range es {
-		r, err := t.tx.Exec(ctx, e.GetSaveQuery(), e.GetSaveArgs()...)
+		err := runHook(ctx, e, entity.Hookable.BeforeSave)
 		if err != nil {
 			return err
 		}
-		if t.o.failOnNoRowsAffected {
-			rows, err := r.RowsAffected()
-			if err != nil {
-				return err
-			}
-			if rows == 0 {
-				return alphasql.ErrNoRowsAffected
-			}
+		err = t.o.save(ctx, t.tx, e)
+		if err != nil {
+			return err
 		}
 	}
-	return nil
+	return runHooks(ctx, es, entity.Hookable.AfterSave)
 }
 
 func (t *transactionalORM) Delete(ctx context.Context, es ...entity.Entity) error {
 	for _, e := range es {
+		err := runHook(ctx, e, entity.Hookable.BeforeDelete)
+		if err != nil {
+			return err
+		}
 		r, err := t.tx.Exec(ctx, e.GetDeleteQuery(), e.GetDeleteArgs()...)
 		if err != nil {
 			return err
@@ -243,7 +286,15 @@ func (t *transactionalORM) Delete(ctx context.Context, es ...entity.Entity) erro
 			}
 		}
 	}
-	return nil
+	return runHooks(ctx, es, entity.Hookable.AfterDelete)
+}
+
+func (t *transactionalORM) DeleteAll(ctx context.Context, e entity.Entity) (int64, error) {
+	r, err := t.tx.Exec(ctx, t.o.getDeleteAllQuery(e))
+	if err != nil {
+		return 0, err
+	}
+	return r.RowsAffected()
 }
 
 func (t *transactionalORM) QueryRow(ctx context.Context, e entity.RawEntity, code int) error {
@@ -251,7 +302,9 @@ func (t *transactionalORM) QueryRow(ctx context.Context, e entity.RawEntity, cod
 	if r.Error() != nil {
 		return r.Error()
 	}
-	return e.BindRow(code, &scannerRow{r: r, isScanToStructureEnabled: t.o.isScanToStructureEnabled})
+	s := &scannerRow{r: r, isScanToStructureEnabled: t.o.isScanToStructureEnabled}
+	defer s.discard(ctx)
+	return e.BindRow(code, s)
 }
 
 func (t *transactionalORM) Query(ctx context.Context, e entity.RawEntity, code int) ([]entity.RawEntity, error) {
@@ -260,6 +313,7 @@ func (t *transactionalORM) Query(ctx context.Context, e entity.RawEntity, code i
 		return nil, err
 	}
 	defer closeRows(ctx, r)
+	name := getEntityName(e)
 	result := make([]entity.RawEntity, 0)
 	for r.Next(ctx) {
 		err = e.BindRow(code, &scannerRows{r: r, isScanToStructureEnabled: t.o.isScanToStructureEnabled})
@@ -269,7 +323,11 @@ func (t *transactionalORM) Query(ctx context.Context, e entity.RawEntity, code i
 		result = append(result, e)
 		e = e.GetNext()
 	}
-	if len(result) == 0 {
+	if err = r.Error(); err != nil {
+		return nil, err
+	}
+	t.o.onRowsFetched(name, len(result))
+	if len(result) == 0 && t.o.emptyResultAsError {
 		return nil, alphasql.ErrNoRows
 	}
 	return result, nil
@@ -277,7 +335,11 @@ func (t *transactionalORM) Query(ctx context.Context, e entity.RawEntity, code i
 
 func (t *transactionalORM) Exec(ctx context.Context, es ...entity.RawExec) error {
 	for _, e := range es {
-		r, err := t.tx.Exec(ctx, e.Entity.GetExec(e.Code), e.Entity.GetExecArgs(e.Code)...)
+		args, err := getRawExecArgs(e)
+		if err != nil {
+			return err
+		}
+		r, err := t.tx.Exec(ctx, e.Entity.GetExec(e.Code), args...)
 		if err != nil {
 			return err
 		}
@@ -302,6 +364,65 @@ func (t *transactionalORM) Rollback(ctx context.Context) error {
 	return t.tx.Rollback(ctx)
 }
 
+func (o *orm) getDeleteAllQuery(e entity.Entity) string {
+	if te, ok := e.(entity.TruncatableEntity); ok && o.truncateOnDeleteAll {
+		return te.GetTruncateQuery()
+	}
+	return e.GetDeleteAllQuery()
+}
+
+func getRawExecArgs(e entity.RawExec) ([]interface{}, error) {
+	if e.Args != nil {
+		return alphasql.StructureArgs(e.Args)
+	}
+	return e.Entity.GetExecArgs(e.Code), nil
+}
+
+// querier is implemented by both the pool and the transactions the entities are read through.
+type querier interface {
+	Query(ctx context.Context, query string, args ...any) (alphasql.Rows, error)
+	QueryRow(ctx context.Context, query string, args ...any) alphasql.Row
+}
+
+func (o *orm) getByID(ctx context.Context, q querier, e entity.ReadableEntity) error {
+	r := q.QueryRow(ctx, e.GetIDQuery(), e.GetIDArgs()...)
+	if r.Error() != nil {
+		return r.Error()
+	}
+	s := &scannerRow{r: r, isScanToStructureEnabled: o.isScanToStructureEnabled}
+	defer s.discard(ctx)
+	return e.BindRow(s)
+}
+
+// fetchAll runs the query, binding its rows to e and to the following entities returned by next, and returns
+// alphasql.ErrNoRows when no rows are selected and emptyAsError is set.
+func fetchAll[T entity.ReadableEntity](ctx context.Context, o *orm, q querier, e T, next func(T) T, query string,
+	args []interface{}, emptyAsError bool) ([]T, error) {
+	r, err := q.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows(ctx, r)
+	name := getEntityName(e)
+	result := make([]T, 0)
+	for r.Next(ctx) {
+		err = e.BindRow(&scannerRows{r: r, isScanToStructureEnabled: o.isScanToStructureEnabled})
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, e)
+		e = next(e)
+	}
+	if err = r.Error(); err != nil {
+		return nil, err
+	}
+	o.onRowsFetched(name, len(result))
+	if len(result) == 0 && emptyAsError {
+		return nil, alphasql.ErrNoRows
+	}
+	return result, nil
+}
+
 func closeRows(ctx context.Context, r alphasql.Rows) {
 	_ = r.Close(ctx)
 }
@@ -309,3 +430,14 @@ func closeRows(ctx context.Context, r alphasql.Rows) {
 func rollbackTX(ctx context.Context, tx alphasql.TX) {
 	_ = tx.Rollback(ctx)
 }
+
+func getEntityName(e any) string {
+	t := reflect.TypeOf(e)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil {
+		return ""
+	}
+	return t.Name()
+}
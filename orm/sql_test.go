@@ -0,0 +1,205 @@
+package orm
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	alphasql "github.com/sinhashubham95/alpha-sql"
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+	"github.com/sinhashubham95/alpha-sql/orm/entity"
+)
+
+func TestOnRowsFetched(t *testing.T) {
+	type fetch struct {
+		name  string
+		count int
+	}
+	var fetches []fetch
+	o := newFakeORM(t, usersDriver(user{ID: 1, Name: "a"}, user{ID: 2, Name: "b"}, user{ID: 3, Name: "c"}),
+		&Configuration{OnRowsFetched: func(name string, count int) { fetches = append(fetches, fetch{name, count}) }})
+
+	us, err := o.GetAll(context.Background(), &user{})
+	if err != nil {
+		t.Fatalf("get all: %v", err)
+	}
+	if len(us) != 3 {
+		t.Fatalf("got %d users, want 3", len(us))
+	}
+	if len(fetches) != 1 || fetches[0] != (fetch{"user", 3}) {
+		t.Fatalf("got %v, want a single fetch of 3 users", fetches)
+	}
+
+	rs, err := o.Query(context.Background(), newRawUser(map[int]string{1: "SELECT id, name FROM users"}, nil), 1)
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	if len(rs) != 3 {
+		t.Fatalf("got %d raw users, want 3", len(rs))
+	}
+	if len(fetches) != 2 || fetches[1] != (fetch{"rawUser", 3}) {
+		t.Fatalf("got %v, want a second fetch of 3 raw users", fetches)
+	}
+}
+
+// truncatableUser is a user whose table may be truncated.
+type truncatableUser struct {
+	user
+}
+
+func (u *truncatableUser) GetTruncateQuery() string {
+	return "TRUNCATE users"
+}
+
+// deleteAllDriver returns a fake driver recording the executions, each of them affecting 3 rows.
+func deleteAllDriver(execs *[]string, commit error) *fakedriver.Driver {
+	return &fakedriver.Driver{
+		Exec: func(_ context.Context, _ *fakedriver.Conn, query string, _ []driver.NamedValue) (driver.Result, error) {
+			*execs = append(*execs, query)
+			return driver.RowsAffected(3), nil
+		},
+		Commit: func(*fakedriver.Conn) error { return commit },
+	}
+}
+
+func TestDeleteAll(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		e        entity.Entity
+		truncate bool
+		want     string
+	}{
+		{"delete", &user{}, false, "DELETE FROM users"},
+		{"delete without truncate", &truncatableUser{}, false, "DELETE FROM users"},
+		{"delete not truncatable", &user{}, true, "DELETE FROM users"},
+		{"truncate", &truncatableUser{}, true, "TRUNCATE users"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var execs []string
+			o := newFakeORM(t, deleteAllDriver(&execs, nil), &Configuration{TruncateOnDeleteAll: tc.truncate})
+			n, err := o.DeleteAll(context.Background(), tc.e)
+			if err != nil {
+				t.Fatalf("delete all: %v", err)
+			}
+			if n != 3 {
+				t.Fatalf("got %d rows affected, want 3", n)
+			}
+			if len(execs) != 1 || execs[0] != tc.want {
+				t.Fatalf("got executions %q, want %q", execs, tc.want)
+			}
+		})
+	}
+}
+
+func TestDeleteAllCommitFailure(t *testing.T) {
+	errCommit := errors.New("commit failure")
+	var execs []string
+	o := newFakeORM(t, deleteAllDriver(&execs, errCommit), nil)
+	n, err := o.DeleteAll(context.Background(), &user{})
+	if !errors.Is(err, errCommit) {
+		t.Fatalf("got %v, want %v", err, errCommit)
+	}
+	if n != 0 {
+		t.Fatalf("got %d rows affected, want 0 as nothing was deleted", n)
+	}
+}
+
+func TestEmptyResultAsError(t *testing.T) {
+	disabled := false
+	tests := []struct {
+		name string
+		cfg  *Configuration
+		err  error
+	}{
+		{name: "default", cfg: &Configuration{}, err: alphasql.ErrNoRows},
+		{name: "disabled", cfg: &Configuration{EmptyResultAsError: &disabled}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := newFakeORM(t, usersDriver(), tt.cfg)
+			ctx := context.Background()
+			ops := map[string]func(ORM) (int, error){
+				"get all": func(o ORM) (int, error) {
+					result, err := o.GetAll(ctx, &user{})
+					return len(result), err
+				},
+				"query": func(o ORM) (int, error) {
+					result, err := o.Query(ctx, newRawUser(map[int]string{1: "SELECT id, name FROM users"}, nil), 1)
+					return len(result), err
+				},
+			}
+			for op, fn := range ops {
+				n, err := fn(o)
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("%s: got %v, want %v", op, err, tt.err)
+				}
+				if n != 0 {
+					t.Fatalf("%s: got %d entities, want none", op, n)
+				}
+			}
+
+			err := o.TransactionFunc(ctx, nil, func(tx TransactionalORM) error {
+				if _, err := tx.GetAll(ctx, &user{}); !errors.Is(err, tt.err) {
+					t.Errorf("get all in a transaction: got %v, want %v", err, tt.err)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("transaction: %v", err)
+			}
+		})
+	}
+}
+
+// failingRows returns the error after the rows read before it.
+type failingRows struct {
+	*fakedriver.Rows
+	after int
+	err   error
+}
+
+func (r *failingRows) Next(dest []driver.Value) error {
+	if r.after == 0 {
+		return r.err
+	}
+	r.after--
+	return r.Rows.Next(dest)
+}
+
+func TestFetchRowsError(t *testing.T) {
+	errRows := errors.New("connection reset")
+	for _, after := range []int{0, 1} {
+		var fetches int
+		o := newFakeORM(t, &fakedriver.Driver{
+			Query: func(_ context.Context, _ *fakedriver.Conn, _ string, _ []driver.NamedValue) (driver.Rows, error) {
+				rows := usersRows(user{ID: 1, Name: "a"}, user{ID: 2, Name: "b"})
+				return &failingRows{Rows: rows, after: after, err: errRows}, nil
+			},
+		}, &Configuration{OnRowsFetched: func(string, int) { fetches++ }})
+		ctx := context.Background()
+		raw := newRawUser(map[int]string{1: "SELECT id, name FROM users"}, nil)
+
+		if _, err := o.GetAll(ctx, &user{}); !errors.Is(err, errRows) {
+			t.Fatalf("after %d rows: get all: got %v, want %v", after, err, errRows)
+		}
+		if _, err := o.Query(ctx, raw, 1); !errors.Is(err, errRows) {
+			t.Fatalf("after %d rows: query: got %v, want %v", after, err, errRows)
+		}
+		err := o.TransactionFunc(ctx, nil, func(tx TransactionalORM) error {
+			if _, err := tx.GetAll(ctx, &user{}); !errors.Is(err, errRows) {
+				t.Errorf("after %d rows: get all in a transaction: got %v, want %v", after, err, errRows)
+			}
+			if _, err := tx.Query(ctx, raw, 1); !errors.Is(err, errRows) {
+				t.Errorf("after %d rows: query in a transaction: got %v, want %v", after, err, errRows)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("after %d rows: transaction: %v", after, err)
+		}
+		if fetches != 0 {
+			t.Fatalf("after %d rows: got the rows fetched reported %d times for failed queries, want 0", after, fetches)
+		}
+	}
+}
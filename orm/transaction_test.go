@@ -0,0 +1,151 @@
+package orm
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	alphasql "github.com/sinhashubham95/alpha-sql"
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+	"github.com/sinhashubham95/alpha-sql/pool"
+)
+
+// deadlockError is a driver error carrying a SQLSTATE code, mapped by deadlockConfig.
+type deadlockError struct {
+	code string
+}
+
+func (e *deadlockError) Error() string {
+	return "sqlstate " + e.code
+}
+
+// deadlockConfig maps the driver errors with the deadlock detected code to alphasql.ErrDeadlock.
+func deadlockConfig(cfg *Configuration) *Configuration {
+	cfg.PoolConfig = &pool.Config{ConnectionConfig: &alphasql.ConnectionConfig{ErrorMap: []alphasql.ErrorMapping{{
+		Match: func(err error) bool {
+			var de *deadlockError
+			return errors.As(err, &de) && de.code == "40P01"
+		},
+		Err: alphasql.ErrDeadlock,
+	}}}}
+	return cfg
+}
+
+// commitDriver returns a fake driver failing the commits with a deadlock until failures commits were made.
+func commitDriver(failures int64, commits, rollbacks *atomic.Int64) *fakedriver.Driver {
+	return &fakedriver.Driver{
+		Commit: func(_ *fakedriver.Conn) error {
+			if commits.Add(1) <= failures {
+				return &deadlockError{code: "40P01"}
+			}
+			return nil
+		},
+		Rollback: func(_ *fakedriver.Conn) error {
+			rollbacks.Add(1)
+			return nil
+		},
+	}
+}
+
+func TestTransactionFuncRetriesDeadlock(t *testing.T) {
+	var commits, rollbacks atomic.Int64
+	o := newFakeORM(t, commitDriver(1, &commits, &rollbacks),
+		deadlockConfig(&Configuration{TransactionRetryDelay: time.Millisecond}))
+
+	attempts := 0
+	err := o.TransactionFunc(context.Background(), nil, func(_ TransactionalORM) error {
+		attempts++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("got %v, want the transaction committed on the retry", err)
+	}
+	if attempts != 2 || commits.Load() != 2 {
+		t.Fatalf("got %d attempts and %d commits, want 2 of each", attempts, commits.Load())
+	}
+}
+
+func TestTransactionFuncAttemptsExhausted(t *testing.T) {
+	var commits, rollbacks atomic.Int64
+	o := newFakeORM(t, commitDriver(10, &commits, &rollbacks),
+		deadlockConfig(&Configuration{TransactionMaxAttempts: 4, TransactionRetryDelay: time.Millisecond}))
+
+	attempts := 0
+	err := o.TransactionFunc(context.Background(), nil, func(_ TransactionalORM) error {
+		attempts++
+		return nil
+	})
+	if !errors.Is(err, alphasql.ErrDeadlock) {
+		t.Fatalf("got %v, want %v", err, alphasql.ErrDeadlock)
+	}
+	if attempts != 4 {
+		t.Fatalf("got %d attempts, want 4", attempts)
+	}
+}
+
+func TestTransactionFuncNotRetryable(t *testing.T) {
+	var commits, rollbacks atomic.Int64
+	o := newFakeORM(t, commitDriver(0, &commits, &rollbacks),
+		deadlockConfig(&Configuration{TransactionRetryDelay: time.Millisecond}))
+
+	errFn := errors.New("invalid user")
+	attempts := 0
+	err := o.TransactionFunc(context.Background(), nil, func(_ TransactionalORM) error {
+		attempts++
+		return errFn
+	})
+	if !errors.Is(err, errFn) {
+		t.Fatalf("got %v, want %v", err, errFn)
+	}
+	if attempts != 1 || rollbacks.Load() != 1 || commits.Load() != 0 {
+		t.Fatalf("got %d attempts, %d rollbacks and %d commits, want a single attempt rolled back",
+			attempts, rollbacks.Load(), commits.Load())
+	}
+}
+
+func TestTransactionFuncIsRetryable(t *testing.T) {
+	var commits, rollbacks atomic.Int64
+	errSerialization := errors.New("could not serialize access")
+	o := newFakeORM(t, commitDriver(0, &commits, &rollbacks), &Configuration{
+		TransactionRetryDelay: time.Millisecond,
+		IsRetryable:           func(err error) bool { return errors.Is(err, errSerialization) },
+	})
+
+	attempts := 0
+	err := o.TransactionFunc(context.Background(), nil, func(_ TransactionalORM) error {
+		attempts++
+		if attempts == 1 {
+			return errSerialization
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("got %v, want the transaction committed on the retry", err)
+	}
+	if attempts != 2 || rollbacks.Load() != 1 || commits.Load() != 1 {
+		t.Fatalf("got %d attempts, %d rollbacks and %d commits, want the first attempt rolled back",
+			attempts, rollbacks.Load(), commits.Load())
+	}
+}
+
+func TestTransactionFuncContextCancelled(t *testing.T) {
+	var commits, rollbacks atomic.Int64
+	o := newFakeORM(t, commitDriver(10, &commits, &rollbacks),
+		deadlockConfig(&Configuration{TransactionRetryDelay: time.Hour}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	attempts := 0
+	err := o.TransactionFunc(ctx, nil, func(_ TransactionalORM) error {
+		attempts++
+		return nil
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want %v", err, context.DeadlineExceeded)
+	}
+	if attempts != 1 {
+		t.Fatalf("got %d attempts, want the back off interrupted after the first", attempts)
+	}
+}
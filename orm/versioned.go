@@ -0,0 +1,31 @@
+package orm
+
+import (
+	"context"
+	alphasql "github.com/sinhashubham95/alpha-sql"
+	"github.com/sinhashubham95/alpha-sql/orm/entity"
+)
+
+// save saves an entity within the transaction, using the versioned save query of the entities implementing
+// entity.VersionedEntity, for which no row being affected means the entity was changed concurrently.
+func (o *orm) save(ctx context.Context, tx alphasql.TX, e entity.Entity) error {
+	if ve, ok := e.(entity.VersionedEntity); ok {
+		r, err := tx.Exec(ctx, ve.GetVersionedSaveQuery(), ve.GetVersionedSaveArgs()...)
+		if err != nil {
+			return err
+		}
+		rows, err := r.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return alphasql.ErrOptimisticLockConflict
+		}
+		return nil
+	}
+	r, err := tx.Exec(ctx, e.GetSaveQuery(), e.GetSaveArgs()...)
+	if err != nil {
+		return err
+	}
+	return o.checkRowsAffected(r)
+}
@@ -0,0 +1,16 @@
+package orm
+
+import (
+	"context"
+	"github.com/sinhashubham95/alpha-sql/orm/entity"
+)
+
+func (o *orm) GetAllViews(ctx context.Context, e entity.ViewEntity) ([]entity.ViewEntity, error) {
+	return fetchAll(ctx, o, o.querier(ctx), e, entity.ViewEntity.GetNextView, e.GetAllQuery(), e.GetAllQueryArgs(),
+		o.emptyResultAsError)
+}
+
+func (t *transactionalORM) GetAllViews(ctx context.Context, e entity.ViewEntity) ([]entity.ViewEntity, error) {
+	return fetchAll(ctx, t.o, t.tx, e, entity.ViewEntity.GetNextView, e.GetAllQuery(), e.GetAllQueryArgs(),
+		t.o.emptyResultAsError)
+}
@@ -0,0 +1,108 @@
+package orm
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	alphasql "github.com/sinhashubham95/alpha-sql"
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+	"github.com/sinhashubham95/alpha-sql/orm/entity"
+)
+
+const userOrdersQuery = "SELECT u.id, u.name, COUNT(o.id) FROM users u LEFT JOIN orders o ON o.user_id = u.id GROUP BY u.id, u.name"
+
+// userOrders is a view joining the users with the count of their orders.
+type userOrders struct {
+	UserID int64
+	Name   string
+	Orders int64
+}
+
+func (v *userOrders) GetIDQuery() string {
+	return userOrdersQuery + " HAVING u.id = $1"
+}
+
+func (v *userOrders) GetIDArgs() []interface{} {
+	return []interface{}{v.UserID}
+}
+
+func (v *userOrders) GetAllQuery() string {
+	return userOrdersQuery
+}
+
+func (v *userOrders) GetAllQueryArgs() []interface{} {
+	return nil
+}
+
+func (v *userOrders) GetNextView() entity.ViewEntity {
+	return &userOrders{}
+}
+
+func (v *userOrders) BindRow(row entity.Scanner) error {
+	return row.Scan(context.Background(), &v.UserID, &v.Name, &v.Orders)
+}
+
+// userOrdersDriver returns a fake driver answering the view queries with the rows of the join, filtered by the id
+// when one is bound.
+func userOrdersDriver() *fakedriver.Driver {
+	rows := [][]driver.Value{{int64(1), "a", int64(3)}, {int64(2), "b", int64(0)}}
+	return &fakedriver.Driver{
+		Query: func(_ context.Context, _ *fakedriver.Conn, _ string, args []driver.NamedValue) (driver.Rows, error) {
+			r := fakedriver.NewRows([]string{"id", "name", "count"})
+			for _, row := range rows {
+				if len(args) == 0 || args[0].Value == row[0] {
+					r.Sets[0].Rows = append(r.Sets[0].Rows, row)
+				}
+			}
+			return r, nil
+		},
+	}
+}
+
+func TestGetAllViews(t *testing.T) {
+	o := newFakeORM(t, userOrdersDriver(), nil)
+	vs, err := o.GetAllViews(context.Background(), &userOrders{})
+	if err != nil {
+		t.Fatalf("get all views: %v", err)
+	}
+	if len(vs) != 2 {
+		t.Fatalf("got %d views, want 2", len(vs))
+	}
+	if v := vs[0].(*userOrders); *v != (userOrders{UserID: 1, Name: "a", Orders: 3}) {
+		t.Fatalf("got %+v, want user 1 with 3 orders", v)
+	}
+	if v := vs[1].(*userOrders); *v != (userOrders{UserID: 2, Name: "b", Orders: 0}) {
+		t.Fatalf("got %+v, want user 2 with no orders", v)
+	}
+}
+
+func TestGetByIDView(t *testing.T) {
+	o := newFakeORM(t, userOrdersDriver(), nil)
+	ctx := context.Background()
+	v := &userOrders{UserID: 1}
+	if err := o.GetByID(ctx, v); err != nil {
+		t.Fatalf("get view by id: %v", err)
+	}
+	if v.Name != "a" || v.Orders != 3 {
+		t.Fatalf("got %+v, want user 1 with 3 orders", v)
+	}
+	if err := o.GetByID(ctx, &userOrders{UserID: 3}); !errors.Is(err, alphasql.ErrNoRows) {
+		t.Fatalf("got %v, want %v", err, alphasql.ErrNoRows)
+	}
+}
+
+func TestGetAllViewsInTransaction(t *testing.T) {
+	o := newFakeORM(t, userOrdersDriver(), nil)
+	err := o.TransactionFunc(context.Background(), nil, func(tx TransactionalORM) error {
+		vs, err := tx.GetAllViews(context.Background(), &userOrders{})
+		if err == nil && len(vs) != 2 {
+			t.Errorf("got %d views, want 2", len(vs))
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatalf("transaction: %v", err)
+	}
+}
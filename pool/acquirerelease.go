@@ -3,6 +3,7 @@ package pool
 import (
 	"context"
 	"errors"
+	"fmt"
 	alphasql "github.com/sinhashubham95/alpha-sql"
 	"github.com/sinhashubham95/go-utils/maths"
 	"time"
@@ -15,6 +16,25 @@ func (p *Pool) Acquire(ctx context.Context) (*Connection, error) {
 		if err != nil {
 			return nil, err
 		}
+		if !c.c.IsValid() {
+			p.validationDestroyCount.Add(1)
+			go p.p.destroyAcquiredConnection(ctx, c)
+			continue
+		}
+		// the session is reset on acquire rather than on release, like database/sql does, so it runs with the
+		// context of the new user instead of the one of a caller that may be gone already
+		if c.needsReset {
+			if err = c.c.ResetSession(ctx); err != nil {
+				if ctx.Err() != nil {
+					p.p.releaseUnused(ctx, c)
+					return nil, ctx.Err()
+				}
+				p.validationDestroyCount.Add(1)
+				go p.p.destroyAcquiredConnection(ctx, c)
+				continue
+			}
+			c.needsReset = false
+		}
 		if c.idleDuration() > time.Second {
 			err = c.Ping(ctx)
 			if err != nil {
@@ -22,29 +42,56 @@ func (p *Pool) Acquire(ctx context.Context) (*Connection, error) {
 				continue
 			}
 		}
-		if p.beforeAcquire(ctx, c) {
-			return c, nil
+		if !p.beforeAcquire(ctx, c) {
+			go p.p.destroyAcquiredConnection(ctx, c)
+			continue
 		}
-		go p.p.destroyAcquiredConnection(ctx, c)
+		// a failing OnAcquire would most likely fail on any other Connection as well, so it is not retried
+		if err = p.onAcquire(ctx, c); err != nil {
+			go p.p.destroyAcquiredConnection(ctx, c)
+			return nil, fmt.Errorf("%w: %w", alphasql.ErrOnAcquireFailed, err)
+		}
+		if p.trackAcquireStacks {
+			p.p.recordAcquireStack(c)
+		}
+		return c, nil
 	}
 }
 
-// Release is used to return a (*Connection) to the pool.
+// AcquireAllIdle is used to acquire all the idle connections in the pool at once, without waiting, for instance to
+// validate or ping all of them. Each of the connections returned must be returned to the pool with [Pool.Release].
+// The number of connections returned may be smaller than the number of idle connections, as the acquisition
+// allowance is taken in decreasing powers of two, and only the part granted without waiting is used.
+func (p *Pool) AcquireAllIdle(_ context.Context) []*Connection {
+	idle := p.p.acquireAllIdleConnections()
+	if p.trackAcquireStacks {
+		for _, c := range idle {
+			p.p.recordAcquireStack(c)
+		}
+	}
+	return idle
+}
+
+// Release is used to return a (*Connection) to the pool. It does not consult Config.ShouldDestroyOnError, use
+// [Pool.ReleaseWithError] to return a Connection an operation failed on. The session of the Connection is reset
+// when it is acquired next.
 func (p *Pool) Release(ctx context.Context, c *Connection) {
 	if c.status != connectionStatusAcquired {
 		return
 	}
 	if p.isExpiredConnection(c) {
-		p.lifetimeDestroyCount.Add(1)
+		p.countExpiredConnectionDestroy(c)
 		go p.p.destroyAcquiredConnection(ctx, c)
 		p.forceTriggerHealthCheck()
 		return
 	}
+	c.needsReset = true
 	go func() {
 		if p.afterRelease(ctx, c) {
 			p.p.release(ctx, c, time.Now().UnixNano())
 		} else {
-			p.lifetimeDestroyCount.Add(1)
+			p.afterReleaseDestroyCount.Add(1)
+			p.p.countAfterReleaseDestroyReason(p.afterReleaseDestroyReason(ctx, c))
 			p.p.destroyAcquiredConnection(ctx, c)
 			p.forceTriggerHealthCheck()
 		}
@@ -142,14 +189,17 @@ func (p *pool) initialiseAcquiredConnection(ctx context.Context, c *Connection)
 	}
 }
 
-func (p *pool) acquireConnection(ctx context.Context, maxConnectionLifetime,
-	maxConnectionLifetimeJitter time.Duration) (*Connection, error) {
+func (p *pool) acquireConnection(ctx context.Context, maxConnectionLifetime, maxConnectionLifetimeJitter,
+	acquireTimeout time.Duration) (*Connection, error) {
 	st := time.Now().UnixNano()
 
 	var waitedForLock bool
 	if !p.acquireSem.TryAcquire(1) {
 		waitedForLock = true
-		err := p.acquireSem.Acquire(ctx, 1)
+		err := p.waitForAcquireSem(ctx, acquireTimeout)
+		if errors.Is(err, alphasql.ErrPoolClosed) {
+			return nil, err
+		}
 		if err != nil {
 			p.canceledAcquireCount.Add(1)
 			return nil, err
@@ -165,8 +215,11 @@ func (p *pool) acquireConnection(ctx context.Context, maxConnectionLifetime,
 
 	// try to get the connection from the pool itself.
 	if c := p.tryAcquireIdleConnection(); c != nil {
+		c.usageCount++
 		if waitedForLock {
 			p.emptyAcquireCount += 1
+		} else {
+			p.idleAcquireCount += 1
 		}
 		p.acquireCount += 1
 		p.acquireDuration += time.Duration(time.Now().UnixNano() - st)
@@ -184,6 +237,7 @@ func (p *pool) acquireConnection(ctx context.Context, maxConnectionLifetime,
 
 	p.mu.Lock()
 	defer p.mu.Unlock()
+	c.usageCount++
 	p.emptyAcquireCount += 1
 	p.acquireCount += 1
 	p.acquireDuration += time.Duration(time.Now().UnixNano() - st)
@@ -191,14 +245,62 @@ func (p *pool) acquireConnection(ctx context.Context, maxConnectionLifetime,
 	return c, nil
 }
 
+// waitForAcquireSem waits for an allowance to acquire a resource, returning alphasql.ErrPoolClosed as soon as
+// the pool is closed, instead of waiting for a Connection to be released. If the timeout is positive, it returns
+// alphasql.ErrAcquireTimeout once the timeout elapses without an allowance.
+func (p *pool) waitForAcquireSem(ctx context.Context, timeout time.Duration) error {
+	var waitCtx context.Context
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		waitCtx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		waitCtx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+	go func() {
+		select {
+		case <-p.baseAcquireCtx.Done():
+			cancel()
+		case <-waitCtx.Done():
+		}
+	}()
+	err := p.acquireSem.Acquire(waitCtx, 1)
+	if err != nil && ctx.Err() == nil {
+		if p.baseAcquireCtx.Err() != nil {
+			return alphasql.ErrPoolClosed
+		}
+		return alphasql.ErrAcquireTimeout
+	}
+	return err
+}
+
 func (p *Pool) acquire(ctx context.Context) (*Connection, error) {
 	select {
 	case <-ctx.Done():
 		p.p.canceledAcquireCount.Add(1)
 		return nil, ctx.Err()
+	case <-p.closeChan:
+		return nil, alphasql.ErrPoolClosed
 	default:
 	}
-	return p.p.acquireConnection(ctx, p.maxConnectionLifetime, p.maxConnectionLifetimeJitter)
+	c, err := p.p.acquireConnection(ctx, p.maxConnectionLifetime, p.maxConnectionLifetimeJitter, p.acquireTimeout)
+	if errors.Is(err, alphasql.ErrAcquireTimeout) {
+		p.acquireRejectedCount.Add(1)
+		p.onAcquireRejected(ctx)
+	}
+	return c, err
+}
+
+func (p *pool) countAfterReleaseDestroyReason(reason string) {
+	if reason == "" {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.afterReleaseDestroyReasons == nil {
+		p.afterReleaseDestroyReasons = make(map[string]int64)
+	}
+	p.afterReleaseDestroyReasons[reason]++
 }
 
 func (p *pool) releaseUnused(ctx context.Context, c *Connection) {
@@ -219,8 +321,18 @@ func (p *pool) release(ctx context.Context, c *Connection, ts int64) {
 	}
 }
 
+// ReleaseWithError is used to return a (*Connection) to the pool after an operation on it failed with err,
+// destroying it instead if err is alphasql.ErrBadConnection or Config.ShouldDestroyOnError returns true for it.
+// A nil err releases the Connection like [Pool.Release].
+func (p *Pool) ReleaseWithError(ctx context.Context, c *Connection, err error) {
+	if c.status != connectionStatusAcquired {
+		return
+	}
+	p.closeOrRelease(ctx, c, err)
+}
+
 func (p *Pool) closeOrRelease(ctx context.Context, c *Connection, err error) {
-	if errors.Is(err, alphasql.ErrBadConnection) {
+	if errors.Is(err, alphasql.ErrBadConnection) || (err != nil && p.shouldDestroyOnError(err)) {
 		go p.p.destroyAcquiredConnection(ctx, c)
 		return
 	}
@@ -0,0 +1,387 @@
+package pool
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	alphasql "github.com/sinhashubham95/alpha-sql"
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+)
+
+var errFatal = errors.New("fatal")
+
+func TestShouldDestroyOnErrorDestroysOnRelease(t *testing.T) {
+	d := &fakedriver.Driver{
+		Exec: func(_ context.Context, _ *fakedriver.Conn, query string, _ []driver.NamedValue) (driver.Result, error) {
+			if query == "fail" {
+				return nil, errFatal
+			}
+			return driver.RowsAffected(1), nil
+		},
+	}
+	p := newFakePool(t, d, &Config{ShouldDestroyOnError: func(err error) bool { return errors.Is(err, errFatal) }})
+	ctx := context.Background()
+
+	if _, err := p.Exec(ctx, "ok"); err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+	eventually(t, func() bool { return p.Stat().IdleConnections() == 1 })
+	if n := d.Closes.Load(); n != 0 {
+		t.Fatalf("got %d connections closed after a successful exec, want 0", n)
+	}
+
+	if _, err := p.Exec(ctx, "fail"); !errors.Is(err, errFatal) {
+		t.Fatalf("got %v, want %v", err, errFatal)
+	}
+	eventually(t, func() bool { return d.Closes.Load() == 1 })
+	eventually(t, func() bool { return p.Stat().TotalConnections() == 0 })
+}
+
+func TestReleaseWithError(t *testing.T) {
+	d := &fakedriver.Driver{}
+	p := newFakePool(t, d, &Config{ShouldDestroyOnError: func(err error) bool { return errors.Is(err, errFatal) }})
+	ctx := context.Background()
+
+	c, err := p.Acquire(ctx)
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	p.ReleaseWithError(ctx, c, errors.New("benign"))
+	eventually(t, func() bool { return p.Stat().IdleConnections() == 1 })
+
+	c, err = p.Acquire(ctx)
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	p.ReleaseWithError(ctx, c, errFatal)
+	eventually(t, func() bool { return d.Closes.Load() == 1 })
+	eventually(t, func() bool { return p.Stat().TotalConnections() == 0 })
+}
+
+func TestAfterReleaseDestroyCount(t *testing.T) {
+	d := &fakedriver.Driver{}
+	p := newFakePool(t, d, &Config{
+		AfterRelease: func(_ context.Context, c *Connection) bool {
+			return c.ID()%2 == 0
+		},
+		AfterReleaseDestroyReason: func(_ context.Context, c *Connection) string {
+			if c.ID() == 1 {
+				return "tainted"
+			}
+			return "stale"
+		},
+	})
+	ctx := context.Background()
+	// the first 3 connections are acquired together, so each of them is a fresh one
+	cs := make([]*Connection, 3)
+	for i := range cs {
+		c, err := p.Acquire(ctx)
+		if err != nil {
+			t.Fatalf("acquire: %v", err)
+		}
+		cs[i] = c
+	}
+	for _, c := range cs {
+		p.Release(ctx, c)
+	}
+	eventually(t, func() bool { return d.Closes.Load() == 2 && p.Stat().IdleConnections() == 1 })
+
+	s := p.Stat()
+	if n := s.AfterReleaseDestroyCount(); n != 2 {
+		t.Fatalf("got %d connections destroyed after release, want 2", n)
+	}
+	if n := s.LifetimeDestroyCount(); n != 0 {
+		t.Fatalf("got %d connections destroyed for their lifetime, want 0", n)
+	}
+	if r := s.AfterReleaseDestroyReasons(); len(r) != 2 || r["tainted"] != 1 || r["stale"] != 1 {
+		t.Fatalf("got reasons %v, want one tainted and one stale", r)
+	}
+}
+
+func TestAfterReleaseDestroyWithoutReason(t *testing.T) {
+	p := newFakePool(t, &fakedriver.Driver{}, &Config{
+		AfterRelease: func(context.Context, *Connection) bool { return false },
+	})
+	ctx := context.Background()
+	c, err := p.Acquire(ctx)
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	p.Release(ctx, c)
+	eventually(t, func() bool { return p.Stat().AfterReleaseDestroyCount() == 1 })
+	if r := p.Stat().AfterReleaseDestroyReasons(); len(r) != 0 {
+		t.Fatalf("got reasons %v, want none without a reason configured", r)
+	}
+}
+
+func TestOnAcquireSetsSession(t *testing.T) {
+	var sets atomic.Int64
+	d := &fakedriver.Driver{
+		Exec: func(_ context.Context, c *fakedriver.Conn, query string, _ []driver.NamedValue) (driver.Result, error) {
+			if role, ok := strings.CutPrefix(query, "SET ROLE "); ok {
+				sets.Add(1)
+				c.Set("role", role)
+			}
+			return driver.RowsAffected(0), nil
+		},
+		Query: func(_ context.Context, c *fakedriver.Conn, _ string, _ []driver.NamedValue) (driver.Rows, error) {
+			return fakedriver.NewRows([]string{"current_role"}, []driver.Value{c.Get("role")}), nil
+		},
+	}
+	p := newFakePool(t, d, &Config{
+		OnAcquire: func(ctx context.Context, c *Connection) error {
+			_, err := c.Exec(ctx, "SET ROLE reader")
+			return err
+		},
+	})
+	ctx := context.Background()
+
+	for i := 1; i <= 2; i++ {
+		c, err := p.Acquire(ctx)
+		if err != nil {
+			t.Fatalf("acquire: %v", err)
+		}
+		var role string
+		err = c.QueryRow(ctx, "SELECT current_role").Scan(ctx, &role)
+		p.Release(ctx, c)
+		if err != nil {
+			t.Fatalf("query: %v", err)
+		}
+		if role != "reader" {
+			t.Fatalf("got role %q, want reader", role)
+		}
+		if n := sets.Load(); n != int64(i) {
+			t.Fatalf("got the role set %d times after %d acquisitions, want once per acquisition", n, i)
+		}
+		eventually(t, func() bool { return p.Stat().IdleConnections() == 1 })
+	}
+}
+
+func TestOnAcquireError(t *testing.T) {
+	errRole := errors.New("role does not exist")
+	d := &fakedriver.Driver{}
+	var fail atomic.Bool
+	fail.Store(true)
+	p := newFakePool(t, d, &Config{
+		OnAcquire: func(context.Context, *Connection) error {
+			if fail.Load() {
+				return errRole
+			}
+			return nil
+		},
+	})
+	ctx := context.Background()
+
+	c, err := p.Acquire(ctx)
+	if !errors.Is(err, alphasql.ErrOnAcquireFailed) || !errors.Is(err, errRole) {
+		t.Fatalf("got %v, want both %v and %v", err, alphasql.ErrOnAcquireFailed, errRole)
+	}
+	if c != nil {
+		t.Fatal("got a connection, want none")
+	}
+	eventually(t, func() bool { return d.Closes.Load() == 1 && p.Stat().TotalConnections() == 0 })
+
+	fail.Store(false)
+	if c, err = p.Acquire(ctx); err != nil {
+		t.Fatalf("acquire once the hook succeeds: %v", err)
+	}
+	p.Release(ctx, c)
+}
+
+func TestResetSessionOnAcquire(t *testing.T) {
+	var resets atomic.Int64
+	d := &fakedriver.Driver{
+		ResetSession: func(_ context.Context, c *fakedriver.Conn) error {
+			resets.Add(1)
+			c.Set("tmp", "")
+			return nil
+		},
+	}
+	p := newFakePool(t, d, nil)
+	ctx := context.Background()
+
+	c, err := p.Acquire(ctx)
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	if n := resets.Load(); n != 0 {
+		t.Fatalf("got %d resets acquiring a fresh connection, want 0", n)
+	}
+	c.c.Connection().(*fakedriver.Conn).Set("tmp", "left by the previous caller")
+	p.Release(ctx, c)
+	eventually(t, func() bool { return p.Stat().IdleConnections() == 1 })
+	if n := resets.Load(); n != 0 {
+		t.Fatalf("got %d resets on release, want the session reset on the next acquire", n)
+	}
+
+	c, err = p.Acquire(ctx)
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	defer p.Release(ctx, c)
+	if n := resets.Load(); n != 1 {
+		t.Fatalf("got %d resets, want 1", n)
+	}
+	if v := c.c.Connection().(*fakedriver.Conn).Get("tmp"); v != "" {
+		t.Fatalf("got %q left in the session, want it reset", v)
+	}
+}
+
+func TestResetSessionFailureDestroysConnection(t *testing.T) {
+	d := &fakedriver.Driver{
+		ResetSession: func(_ context.Context, c *fakedriver.Conn) error {
+			if c.ID == 1 {
+				return driver.ErrBadConn
+			}
+			return nil
+		},
+	}
+	p := newFakePool(t, d, nil)
+	ctx := context.Background()
+
+	c, err := p.Acquire(ctx)
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	p.Release(ctx, c)
+	eventually(t, func() bool { return p.Stat().IdleConnections() == 1 })
+
+	c, err = p.Acquire(ctx)
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	defer p.Release(ctx, c)
+	if id := c.c.Connection().(*fakedriver.Conn).ID; id != 2 {
+		t.Fatalf("got connection %d, want a new one replacing the one failing to reset", id)
+	}
+	eventually(t, func() bool { return d.Closes.Load() == 1 })
+	if n := p.Stat().ValidationDestroyCount(); n != 1 {
+		t.Fatalf("got %d connections destroyed failing the validation, want 1", n)
+	}
+}
+
+func TestConnectionIDs(t *testing.T) {
+	d := &fakedriver.Driver{}
+	p := newFakePool(t, d, &Config{
+		MaxConnections:       3,
+		ShouldDestroyOnError: func(err error) bool { return errors.Is(err, errFatal) },
+	})
+	ctx := context.Background()
+	acquireAll := func() []*Connection {
+		cs := make([]*Connection, 3)
+		for i := range cs {
+			c, err := p.Acquire(ctx)
+			if err != nil {
+				t.Fatalf("acquire: %v", err)
+			}
+			cs[i] = c
+		}
+		return cs
+	}
+
+	// the ids of the connections, by the id of the driver connection they wrap
+	ids := make(map[int64]uint64)
+	var last uint64
+	cs := acquireAll()
+	for _, c := range cs {
+		if c.ID() <= last {
+			t.Fatalf("got id %d after %d, want the ids increasing", c.ID(), last)
+		}
+		last = c.ID()
+		ids[c.c.Connection().(*fakedriver.Conn).ID] = c.ID()
+		p.Release(ctx, c)
+	}
+	eventually(t, func() bool { return p.Stat().IdleConnections() == 3 })
+
+	cs = acquireAll()
+	for _, c := range cs {
+		if id := ids[c.c.Connection().(*fakedriver.Conn).ID]; c.ID() != id {
+			t.Fatalf("got id %d once acquired again, want %d", c.ID(), id)
+		}
+	}
+	p.ReleaseWithError(ctx, cs[0], errFatal)
+	p.Release(ctx, cs[1])
+	p.Release(ctx, cs[2])
+	eventually(t, func() bool { return p.Stat().TotalConnections() == 2 && p.Stat().IdleConnections() == 2 })
+
+	created := 0
+	for _, c := range acquireAll() {
+		if id, ok := ids[c.c.Connection().(*fakedriver.Conn).ID]; !ok {
+			created++
+			if c.ID() <= last {
+				t.Fatalf("got id %d for the connection replacing a destroyed one, want it greater than %d", c.ID(), last)
+			}
+		} else if c.ID() != id {
+			t.Fatalf("got id %d once acquired again, want %d", c.ID(), id)
+		}
+		p.Release(ctx, c)
+	}
+	if created != 1 {
+		t.Fatalf("got %d connections created, want 1", created)
+	}
+}
+
+func TestShouldDestroyOnErrorQueryRow(t *testing.T) {
+	d := &fakedriver.Driver{
+		Query: func(_ context.Context, _ *fakedriver.Conn, _ string, _ []driver.NamedValue) (driver.Rows, error) {
+			return nil, errFatal
+		},
+	}
+	p := newFakePool(t, d, &Config{ShouldDestroyOnError: func(err error) bool { return errors.Is(err, errFatal) }})
+	ctx := context.Background()
+
+	r := p.QueryRow(ctx, "SELECT 1")
+	var v int64
+	if err := r.Scan(ctx, &v); !errors.Is(err, errFatal) {
+		t.Fatalf("got %v, want %v", err, errFatal)
+	}
+	eventually(t, func() bool { return d.Closes.Load() == 1 })
+	eventually(t, func() bool { return p.Stat().TotalConnections() == 0 })
+
+	// the connection destroyed once leaves the pool usable
+	c, err := p.Acquire(ctx)
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	p.Release(ctx, c)
+	if n := p.Stat().TotalConnections(); n != 1 {
+		t.Fatalf("got %d connections, want 1", n)
+	}
+}
+
+func TestShouldDestroyOnErrorRowsClose(t *testing.T) {
+	d := &fakedriver.Driver{
+		Query: func(_ context.Context, _ *fakedriver.Conn, query string, _ []driver.NamedValue) (driver.Rows, error) {
+			r := fakedriver.NewRows([]string{"id"}, []driver.Value{int64(1)})
+			if query == "fail" {
+				r.CloseErr = errFatal
+			}
+			return r, nil
+		},
+	}
+	p := newFakePool(t, d, &Config{ShouldDestroyOnError: func(err error) bool { return errors.Is(err, errFatal) }})
+	ctx := context.Background()
+
+	for _, query := range []string{"ok", "fail"} {
+		r, err := p.Query(ctx, query)
+		if err != nil {
+			t.Fatalf("query: %v", err)
+		}
+		// closed before being exhausted, so the error of closing them is returned
+		if !r.Next(ctx) {
+			t.Fatalf("next: %v", r.Error())
+		}
+		if err = r.Close(ctx); (query == "fail") != errors.Is(err, errFatal) {
+			t.Fatalf("got %v closing the rows of %q", err, query)
+		}
+		if query == "ok" {
+			eventually(t, func() bool { return p.Stat().IdleConnections() == 1 })
+		}
+	}
+	eventually(t, func() bool { return d.Closes.Load() == 1 })
+	eventually(t, func() bool { return p.Stat().TotalConnections() == 0 })
+}
@@ -0,0 +1,116 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	alphasql "github.com/sinhashubham95/alpha-sql"
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+)
+
+func TestOnDrainComplete(t *testing.T) {
+	d := &fakedriver.Driver{}
+	var calls, closesAtDrain atomic.Int64
+	p := newFakePool(t, d, &Config{
+		MinConnections:    3,
+		SynchronousWarmup: true,
+		OnDrainComplete: func() {
+			calls.Add(1)
+			closesAtDrain.Store(d.Closes.Load())
+		},
+	})
+	ctx := context.Background()
+	c, err := p.Acquire(ctx)
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	p.Release(ctx, c)
+	if n := calls.Load(); n != 0 {
+		t.Fatalf("got the hook called %d times before close, want 0", n)
+	}
+
+	p.Close(ctx)
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("got the hook called %d times once close returned, want 1", n)
+	}
+	if closes, connects := closesAtDrain.Load(), d.Connects.Load(); closes != connects {
+		t.Fatalf("got %d of %d connections closed when the hook was called, want all of them", closes, connects)
+	}
+
+	p.Close(ctx)
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("got the hook called %d times after closing twice, want 1", n)
+	}
+}
+
+func TestCloseDestroysEachIdleConnectionOnce(t *testing.T) {
+	d := &fakedriver.Driver{}
+	var closes atomic.Int64
+	p := newFakePool(t, d, &Config{
+		MinConnections:    4,
+		MaxConnections:    4,
+		SynchronousWarmup: true,
+		BeforeClose:       func(context.Context, *alphasql.Connection) { closes.Add(1) },
+	})
+	if n := p.Stat().IdleConnections(); n != 4 {
+		t.Fatalf("got %d idle connections, want 4", n)
+	}
+
+	p.Close(context.Background())
+	if n := d.Closes.Load(); n != 4 {
+		t.Fatalf("got %d connections closed, want 4", n)
+	}
+	if n := closes.Load(); n != 4 {
+		t.Fatalf("got the before close hook called %d times, want once for each of the 4 connections", n)
+	}
+	if n := p.Stat().TotalConnections(); n != 0 {
+		t.Fatalf("got %d connections left, want 0", n)
+	}
+}
+
+func TestCloseUnblocksAcquire(t *testing.T) {
+	p := newFakePool(t, &fakedriver.Driver{}, &Config{MaxConnections: 1})
+	ctx := context.Background()
+	held, err := p.Acquire(ctx)
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+
+	acquired := make(chan error, 1)
+	go func() {
+		c, err := p.Acquire(ctx)
+		if c != nil {
+			p.Release(ctx, c)
+		}
+		acquired <- err
+	}()
+	select {
+	case err = <-acquired:
+		t.Fatalf("got %v, want the acquire blocked while the only connection is held", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		p.Close(ctx)
+		close(closed)
+	}()
+	select {
+	case err = <-acquired:
+		if !errors.Is(err, alphasql.ErrPoolClosed) {
+			t.Fatalf("got %v, want %v", err, alphasql.ErrPoolClosed)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the blocked acquire did not return once the pool was closed")
+	}
+	if _, err = p.Acquire(ctx); !errors.Is(err, alphasql.ErrPoolClosed) {
+		t.Fatalf("got %v acquiring after close, want %v", err, alphasql.ErrPoolClosed)
+	}
+
+	// Close only returns once the held connection is released
+	p.Release(ctx, held)
+	<-closed
+}
@@ -3,9 +3,21 @@ package pool
 import (
 	"context"
 	alphasql "github.com/sinhashubham95/alpha-sql"
+	"runtime"
 	"time"
 )
 
+// IdleSelectionPolicy decides which idle Connection is reused first when a Connection is acquired.
+type IdleSelectionPolicy string
+
+// idle selection policies
+const (
+	// IdleSelectionPolicyLIFO reuses the most recently released Connection first, keeping a small working set hot.
+	IdleSelectionPolicyLIFO IdleSelectionPolicy = "LIFO"
+	// IdleSelectionPolicyFIFO reuses the least recently released Connection first, spreading the load evenly.
+	IdleSelectionPolicyFIFO IdleSelectionPolicy = "FIFO"
+)
+
 // Config is the configuration required for creating a pool.
 type Config struct {
 	ConnectionConfig *alphasql.ConnectionConfig
@@ -22,13 +34,37 @@ type Config struct {
 	// acquired.
 	BeforeAcquire func(context.Context, *Connection) bool
 
+	// OnAcquire is called after a Connection is selected and accepted by BeforeAcquire, but before it is returned
+	// to the caller, allowing to prepare the Connection for each acquisition, for instance by running `SET ROLE`.
+	// If it returns an error, the Connection is destroyed and Pool.Acquire fails with the error, wrapped with
+	// alphasql.ErrOnAcquireFailed.
+	OnAcquire func(context.Context, *Connection) error
+
+	// OnAcquireRejected is called when an acquisition is rejected because no Connection became available within
+	// AcquireTimeout, for instance to record the load shed.
+	OnAcquireRejected func(context.Context)
+
 	// AfterRelease is called after a Connection is released, but before it is returned to the pool. It must return true to
 	// return the Connection to the pool or false to destroy the Connection.
 	AfterRelease func(context.Context, *Connection) bool
 
+	// AfterReleaseDestroyReason is called when AfterRelease returns false, to get the reason the Connection is
+	// destroyed for. The destructions are counted by their reasons in the pool statistics.
+	AfterReleaseDestroyReason func(context.Context, *Connection) string
+
 	// BeforeClose is called right before a Connection is closed and removed from the pool.
 	BeforeClose func(context.Context, *alphasql.Connection)
 
+	// OnDrainComplete is called once after Pool.Close has destroyed all the connections, so that the shutdown steps
+	// depending on the pool can be chained.
+	OnDrainComplete func()
+
+	// ShouldDestroyOnError is called when an operation on an acquired Connection fails, before it is returned to the
+	// pool. It must return true to destroy the Connection instead of returning it to the pool. Connections failing with
+	// alphasql.ErrBadConnection are always destroyed. It is consulted by the operations of the pool and by
+	// Pool.ReleaseWithError, but not by Pool.Release.
+	ShouldDestroyOnError func(error) bool
+
 	// MaxConnectionLifetime is the duration since creation after which a Connection will be automatically closed.
 	MaxConnectionLifetime time.Duration
 
@@ -42,29 +78,97 @@ type Config struct {
 	// MaxConnections is the maximum size of the pool. The default is the greatest of 4 or runtime.NumCPU().
 	MaxConnections int32
 
-	// MinConnections is the minimum size of the pool. After Connection closes, the pool might dip below MinConnections.
+	// MinConnections is the minimum size of the pool, and it must not exceed MaxConnections. After Connection closes,
+	// the pool might dip below MinConnections.
 	// A low number of MinConnections might mean the pool is empty after MaxConnectionLifetime until the health check
 	// has a chance to create new connections.
 	MinConnections int32
 
 	// HealthCheckPeriod is the duration between checks of the health of idle connections.
 	HealthCheckPeriod time.Duration
+
+	// SynchronousWarmup makes New block until MinConnections are established, returning the error if any of them
+	// fails, instead of establishing them in the background.
+	SynchronousWarmup bool
+
+	// RequireMinConnectionsAtStartup makes New return alphasql.ErrMinConnectionsNotMet when fewer than
+	// MinConnections could be established, wrapping the error of establishing them if any, instead of returning a
+	// degraded pool. It requires SynchronousWarmup.
+	RequireMinConnectionsAtStartup bool
+
+	// ValidateOnHealthCheck enables probing the idle connections during the health check, destroying the ones
+	// failing the probe so that silently dead connections are not handed out.
+	ValidateOnHealthCheck bool
+
+	// HealthCheckQuery is the query run to probe the idle connections when ValidateOnHealthCheck is enabled.
+	// If it is empty, the connections are probed with a Ping.
+	HealthCheckQuery string
+
+	// PinPreparedStatements decides the Connection used by the statements returned from Pool.Prepare. When it is true,
+	// the Connection the statement is prepared on stays acquired until the statement is closed, the concurrent calls
+	// on the statement are serialised, and the rows of a query must be closed before the statement is used again.
+	// Otherwise, a Connection is acquired and the statement is prepared again on it for every execution, releasing the
+	// Connection once the execution completes.
+	PinPreparedStatements bool
+
+	// IdleSelectionPolicy decides which idle Connection is reused first when a Connection is acquired.
+	// The default is IdleSelectionPolicyLIFO.
+	IdleSelectionPolicy IdleSelectionPolicy
+
+	// TrackAcquireStacks enables recording the stack of the caller on every acquisition, so that the connections
+	// held for longer than LeakThreshold can be reported along with where they were acquired by
+	// Pool.LeakedConnections. It is meant for debugging, as recording the stack is expensive.
+	TrackAcquireStacks bool
+
+	// LeakThreshold is the duration after which an acquired Connection is reported by Pool.LeakedConnections.
+	LeakThreshold time.Duration
+
+	// AcquireTimeout is the maximum duration Pool.Acquire waits for a Connection to be available when the pool is
+	// exhausted, independently of the deadline of the context passed, after which alphasql.ErrAcquireTimeout is
+	// returned. The default is 0, meaning the acquisition waits until the context is done.
+	AcquireTimeout time.Duration
 }
 
 // default functions for pool configs.
 var (
-	defaultBeforeConnect         = func(_ context.Context, _ *alphasql.ConnectionConfig) error { return nil }
-	defaultAfterConnect          = func(_ context.Context, _ *alphasql.Connection) error { return nil }
-	defaultBeforeAcquire         = func(_ context.Context, _ *Connection) bool { return true }
-	defaultAfterRelease          = func(_ context.Context, _ *Connection) bool { return true }
-	defaultBeforeClose           = func(_ context.Context, _ *alphasql.Connection) {}
-	defaultMaxConnectionLifetime = time.Hour
-	defaultMaxConnectionIdleTime = time.Minute * 30
-	defaultMaxConnections        = int32(4)
-	defaultMinConnections        = int32(0)
-	defaultHealthCheckPeriod     = time.Minute
+	defaultBeforeConnect             = func(_ context.Context, _ *alphasql.ConnectionConfig) error { return nil }
+	defaultAfterConnect              = func(_ context.Context, _ *alphasql.Connection) error { return nil }
+	defaultBeforeAcquire             = func(_ context.Context, _ *Connection) bool { return true }
+	defaultOnAcquire                 = func(_ context.Context, _ *Connection) error { return nil }
+	defaultOnAcquireRejected         = func(_ context.Context) {}
+	defaultAfterRelease              = func(_ context.Context, _ *Connection) bool { return true }
+	defaultAfterReleaseDestroyReason = func(_ context.Context, _ *Connection) string { return "" }
+	defaultBeforeClose               = func(_ context.Context, _ *alphasql.Connection) {}
+	defaultShouldDestroyOnError      = func(_ error) bool { return false }
+	defaultOnDrainComplete           = func() {}
+	defaultMaxConnectionLifetime     = time.Hour
+	defaultMaxConnectionIdleTime     = time.Minute * 30
+	defaultMaxConnections            = getDefaultMaxConnections()
+	defaultMinConnections            = int32(0)
+	defaultHealthCheckPeriod         = time.Minute
+	defaultIdleSelectionPolicy       = IdleSelectionPolicyLIFO
+	defaultLeakThreshold             = time.Minute
+	defaultAcquireTimeout            = time.Duration(0)
 )
 
+// getDefaultMaxConnections returns the greatest of 4 or runtime.NumCPU().
+func getDefaultMaxConnections() int32 {
+	if n := int32(runtime.NumCPU()); n > 4 {
+		return n
+	}
+	return 4
+}
+
+// Copy is used to copy the pool config, along with a copy of its connection config as per
+// alphasql.ConnectionConfig.Copy. The callbacks are shared with the config copied.
+func (c *Config) Copy() *Config {
+	cc := *c
+	if c.ConnectionConfig != nil {
+		cc.ConnectionConfig = c.ConnectionConfig.Copy()
+	}
+	return &cc
+}
+
 // ValidateAndDefault is used to validate and set the defaults for the mandatory parameters not passed.
 func (c *Config) ValidateAndDefault() error {
 	if c.ConnectionConfig == nil {
@@ -83,12 +187,27 @@ func (c *Config) ValidateAndDefault() error {
 	if c.BeforeAcquire == nil {
 		c.BeforeAcquire = defaultBeforeAcquire
 	}
+	if c.OnAcquire == nil {
+		c.OnAcquire = defaultOnAcquire
+	}
+	if c.OnAcquireRejected == nil {
+		c.OnAcquireRejected = defaultOnAcquireRejected
+	}
 	if c.AfterRelease == nil {
 		c.AfterRelease = defaultAfterRelease
 	}
+	if c.AfterReleaseDestroyReason == nil {
+		c.AfterReleaseDestroyReason = defaultAfterReleaseDestroyReason
+	}
 	if c.BeforeClose == nil {
 		c.BeforeClose = defaultBeforeClose
 	}
+	if c.OnDrainComplete == nil {
+		c.OnDrainComplete = defaultOnDrainComplete
+	}
+	if c.ShouldDestroyOnError == nil {
+		c.ShouldDestroyOnError = defaultShouldDestroyOnError
+	}
 	if c.MaxConnectionLifetime == 0 {
 		c.MaxConnectionLifetime = defaultMaxConnectionLifetime
 	}
@@ -104,5 +223,23 @@ func (c *Config) ValidateAndDefault() error {
 	if c.HealthCheckPeriod == 0 {
 		c.HealthCheckPeriod = defaultHealthCheckPeriod
 	}
+	if c.LeakThreshold == 0 {
+		c.LeakThreshold = defaultLeakThreshold
+	}
+	if c.AcquireTimeout < 0 {
+		c.AcquireTimeout = defaultAcquireTimeout
+	}
+	if c.IdleSelectionPolicy == "" {
+		c.IdleSelectionPolicy = defaultIdleSelectionPolicy
+	}
+	if c.IdleSelectionPolicy != IdleSelectionPolicyLIFO && c.IdleSelectionPolicy != IdleSelectionPolicyFIFO {
+		return alphasql.ErrInvalidIdleSelectionPolicy
+	}
+	if c.MinConnections > c.MaxConnections {
+		return alphasql.ErrInvalidMinConnections
+	}
+	if c.RequireMinConnectionsAtStartup && !c.SynchronousWarmup {
+		return alphasql.ErrMinConnectionsWithoutWarmup
+	}
 	return nil
 }
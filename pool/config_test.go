@@ -0,0 +1,24 @@
+package pool
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+)
+
+func TestDefaultMaxConnections(t *testing.T) {
+	want := int32(runtime.NumCPU())
+	if want < 4 {
+		want = 4
+	}
+	p := newFakePool(t, &fakedriver.Driver{}, nil)
+	if n := p.Config().MaxConnections; n != want {
+		t.Fatalf("got %d maximum connections by default with %d CPUs, want %d", n, runtime.NumCPU(), want)
+	}
+
+	p = newFakePool(t, &fakedriver.Driver{}, &Config{MaxConnections: 2})
+	if n := p.Config().MaxConnections; n != 2 {
+		t.Fatalf("got %d maximum connections, want the 2 set", n)
+	}
+}
@@ -17,10 +17,26 @@ const (
 // Connection is used for managing the instance of a Connection in the pool.
 type Connection struct {
 	c            *alphasql.Connection
+	id           uint64
 	creationTime time.Time
 	maxAgeTime   time.Time
 	lastUsedNano int64
+	generation   int64
+	usageCount   int64
 	status       byte
+
+	// needsReset is set when the Connection is released, so its session is reset on the next acquire.
+	needsReset bool
+
+	// acquiredAt and acquireStack are only recorded when Config.TrackAcquireStacks is set.
+	acquiredAt   time.Time
+	acquireStack []byte
+}
+
+// ID returns the id of the Connection, unique within the pool and increasing in the order the connections are
+// created, so the connections can be told apart in the logs and the diagnostics of the pool.
+func (c *Connection) ID() uint64 {
+	return c.id
 }
 
 // Ping verifies a Connection to the database is still alive,
@@ -29,6 +45,17 @@ func (c *Connection) Ping(ctx context.Context) error {
 	return c.c.Ping(ctx)
 }
 
+// PingWithRetry verifies a Connection to the database is still alive, retrying while the Connection is bad.
+// See [alphasql.Connection.PingWithRetry] for details.
+func (c *Connection) PingWithRetry(ctx context.Context, attempts int, backoff time.Duration) error {
+	return c.c.PingWithRetry(ctx, attempts, backoff)
+}
+
+// Capabilities returns which of the optional driver interfaces the underlying driver connection implements.
+func (c *Connection) Capabilities() alphasql.Capabilities {
+	return c.c.Capabilities()
+}
+
 // Query executes a query that returns rows, typically a SELECT.
 // The args are for any placeholder parameters in the query.
 func (c *Connection) Query(ctx context.Context, query string, args ...any) (alphasql.Rows, error) {
@@ -51,6 +78,18 @@ func (c *Connection) Exec(ctx context.Context, query string, args ...any) (alpha
 	return c.c.Exec(ctx, query, args...)
 }
 
+// Insert executes an insert query and returns the id generated for the inserted row.
+// See [alphasql.Connection.Insert] for details.
+func (c *Connection) Insert(ctx context.Context, query string, args ...any) (int64, error) {
+	return c.c.Insert(ctx, query, args...)
+}
+
+// ExecNamed executes a query without returning any rows, reusing the statement prepared under the name
+// provided on this Connection. See [alphasql.Connection.ExecNamed] for details.
+func (c *Connection) ExecNamed(ctx context.Context, name, query string, args ...any) (alphasql.Result, error) {
+	return c.c.ExecNamed(ctx, name, query, args...)
+}
+
 // Prepare creates a prepared statement for later queries or executions.
 // Multiple queries or executions may be run concurrently from the
 // returned statement.
@@ -70,6 +109,7 @@ func (c *Connection) Prepare(ctx context.Context, query string) (alphasql.Statem
 // The provided [TXOptions] is optional and may be nil if defaults should be used.
 // If a non-default isolation level is used that the driver doesn't support,
 // an error will be returned.
+// If a transaction is already in progress on the connection, [alphasql.ErrTransactionInProgress] is returned.
 func (c *Connection) BeginTX(ctx context.Context, options *alphasql.TXOptions) (alphasql.TX, error) {
 	return c.c.BeginTX(ctx, options)
 }
@@ -77,9 +117,11 @@ func (c *Connection) BeginTX(ctx context.Context, options *alphasql.TXOptions) (
 func (p *pool) newConnection(maxConnectionLifetime, maxConnectionLifetimeJitter time.Duration) *Connection {
 	jitterSeconds := rand.Float64() * maxConnectionLifetimeJitter.Seconds()
 	c := &Connection{
+		id:           p.lastConnectionID.Add(1),
 		creationTime: time.Now(),
 		maxAgeTime:   time.Now().Add(maxConnectionLifetime).Add(time.Duration(jitterSeconds) * time.Second),
 		lastUsedNano: time.Now().UnixNano(),
+		generation:   p.generation.Load(),
 		status:       connectionStatusInitialising,
 	}
 	p.allConnections = append(p.allConnections, c)
@@ -94,7 +136,7 @@ func (p *Pool) constructor(ctx context.Context) (*alphasql.Connection, error) {
 	if err != nil {
 		return nil, err
 	}
-	c, err := p.db.Connect(ctx)
+	c, err := p.db.ConnectWithConfig(ctx, cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -115,7 +157,22 @@ func (p *Pool) destructor(ctx context.Context, c *alphasql.Connection) error {
 }
 
 func (p *Pool) isExpiredConnection(c *Connection) bool {
-	return time.Now().After(c.maxAgeTime)
+	return time.Now().After(c.maxAgeTime) || p.isRecycledConnection(c)
+}
+
+// isRecycledConnection reports whether the Connection was created before the last [Pool.Reset] or
+// [Pool.RotateCredentials].
+func (p *Pool) isRecycledConnection(c *Connection) bool {
+	return c.generation < p.p.generation.Load()
+}
+
+// countExpiredConnectionDestroy counts the destroy of an expired Connection under the reason it expired for.
+func (p *Pool) countExpiredConnectionDestroy(c *Connection) {
+	if p.isRecycledConnection(c) {
+		p.resetDestroyCount.Add(1)
+	} else {
+		p.lifetimeDestroyCount.Add(1)
+	}
 }
 
 func (p *pool) destroyConnection(ctx context.Context, c *Connection) {
@@ -0,0 +1,66 @@
+package pool
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Dump returns a human-readable snapshot of the internal state of the pool, listing every Connection by its id with
+// its status, age, idle duration and the number of times it was acquired, followed by the aggregate statistics.
+// It is meant for diagnosing stuck pools, and its format is not stable.
+func (p *Pool) Dump() string {
+	p.p.mu.Lock()
+	defer p.p.mu.Unlock()
+
+	now := time.Now()
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "pool: closed=%t generation=%d connections=%d\n",
+		p.p.closed, p.p.generation.Load(), len(p.p.allConnections))
+	for _, c := range p.p.allConnections {
+		_, _ = fmt.Fprintf(&b, "  connection %d: status=%s age=%s idle=%s usage=%d generation=%d\n",
+			c.id, getConnectionStatusName(c.status), now.Sub(c.creationTime).Round(time.Millisecond),
+			c.dumpIdleDuration(now), c.usageCount, c.generation)
+	}
+
+	s := p.statLocked()
+	_, _ = fmt.Fprintf(&b, "stat: total=%d idle=%d acquired=%d constructing=%d min=%d max=%d\n",
+		s.totalConnections, s.idleConnections, s.acquiredConnections, s.constructingConnections,
+		s.minConnections, s.maxConnections)
+	_, _ = fmt.Fprintf(&b, "stat: acquires=%d duration=%s empty=%d idle=%d canceled=%d rejected=%d\n",
+		s.acquireCount, s.acquireDuration, s.emptyAcquireCount, s.idleAcquireCount, s.canceledAcquireCount,
+		s.acquireRejectedCount)
+	_, _ = fmt.Fprintf(&b, "stat: new=%d lifetime-destroys=%d reset-destroys=%d idle-destroys=%d "+
+		"validation-destroys=%d after-release-destroys=%d resets=%d\n", s.newConnectionsCount,
+		s.lifetimeDestroyCount, s.resetDestroyCount, s.idleDestroyCount, s.validationDestroyCount,
+		s.afterReleaseDestroyCount, s.resetCount)
+	reasons := make([]string, 0, len(s.afterReleaseDestroyReasons))
+	for reason := range s.afterReleaseDestroyReasons {
+		reasons = append(reasons, reason)
+	}
+	sort.Strings(reasons)
+	for _, reason := range reasons {
+		_, _ = fmt.Fprintf(&b, "stat: after-release-destroys[%s]=%d\n", reason, s.afterReleaseDestroyReasons[reason])
+	}
+	return b.String()
+}
+
+func getConnectionStatusName(status byte) string {
+	switch status {
+	case connectionStatusIdle:
+		return "idle"
+	case connectionStatusAcquired:
+		return "acquired"
+	default:
+		return "initialising"
+	}
+}
+
+// dumpIdleDuration returns the idle duration of an idle Connection, or "-" for the other statuses.
+func (c *Connection) dumpIdleDuration(now time.Time) string {
+	if c.status != connectionStatusIdle {
+		return "-"
+	}
+	return time.Duration(now.UnixNano() - c.lastUsedNano).Round(time.Millisecond).String()
+}
@@ -0,0 +1,60 @@
+package pool
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+)
+
+func TestDump(t *testing.T) {
+	p := newFakePool(t, &fakedriver.Driver{}, &Config{
+		MinConnections:    2,
+		MaxConnections:    3,
+		SynchronousWarmup: true,
+		AfterRelease:      func(context.Context, *Connection) bool { return false },
+		AfterReleaseDestroyReason: func(context.Context, *Connection) string {
+			return "tainted"
+		},
+	})
+	ctx := context.Background()
+	c, err := p.Acquire(ctx)
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	acquiredID := c.ID()
+
+	dump := p.Dump()
+	for _, want := range []string{
+		"pool: closed=false generation=0 connections=2\n",
+		fmt.Sprintf("  connection %d: status=acquired ", acquiredID),
+		" idle=- usage=1 ",
+		"status=idle ",
+		"stat: total=2 idle=1 acquired=1 constructing=0 min=2 max=3\n",
+		"stat: acquires=1 ",
+		"stat: new=2 ",
+	} {
+		if !strings.Contains(dump, want) {
+			t.Errorf("got dump\n%s\nwant it to include %q", dump, want)
+		}
+	}
+	if n := strings.Count(dump, "  connection "); n != 2 {
+		t.Errorf("got %d connections listed, want 2", n)
+	}
+
+	p.Release(ctx, c)
+	eventually(t, func() bool { return p.Stat().AfterReleaseDestroyCount() == 1 })
+	p.Close(ctx)
+	dump = p.Dump()
+	for _, want := range []string{
+		"pool: closed=true ",
+		" after-release-destroys=1 ",
+		"stat: after-release-destroys[tainted]=1\n",
+	} {
+		if !strings.Contains(dump, want) {
+			t.Errorf("got dump\n%s\nwant it to include %q", dump, want)
+		}
+	}
+}
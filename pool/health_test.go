@@ -0,0 +1,101 @@
+package pool
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+)
+
+var errDeadConnection = errors.New("dead connection")
+
+// fillIdle acquires count connections at once and releases them, leaving them idle in the pool.
+func fillIdle(t *testing.T, p *Pool, count int) {
+	t.Helper()
+	ctx := context.Background()
+	cs := make([]*Connection, count)
+	for i := range cs {
+		c, err := p.Acquire(ctx)
+		if err != nil {
+			t.Fatalf("acquire: %v", err)
+		}
+		cs[i] = c
+	}
+	for _, c := range cs {
+		p.Release(ctx, c)
+	}
+	eventually(t, func() bool { return p.Stat().IdleConnections() == int32(count) })
+}
+
+func TestValidateOnHealthCheckWithPing(t *testing.T) {
+	var failing atomic.Bool
+	d := &fakedriver.Driver{Ping: func(_ context.Context, c *fakedriver.Conn) error {
+		if failing.Load() && c.ID != 2 {
+			return errDeadConnection
+		}
+		return nil
+	}}
+	p := newFakePool(t, d, &Config{ValidateOnHealthCheck: true, HealthCheckPeriod: 10 * time.Millisecond})
+	fillIdle(t, p, 3)
+
+	failing.Store(true)
+	eventually(t, func() bool { return p.Stat().ValidationDestroyCount() == 2 })
+	eventually(t, func() bool { return p.Stat().TotalConnections() == 1 })
+	if ids := connectionIDs(p); len(ids) != 1 || ids[0] != 2 {
+		t.Fatalf("got connections %v, want only the healthy one", ids)
+	}
+}
+
+func TestValidateOnHealthCheckWithQuery(t *testing.T) {
+	var failing atomic.Bool
+	var probes atomic.Int64
+	d := &fakedriver.Driver{
+		Exec: func(_ context.Context, c *fakedriver.Conn, query string, _ []driver.NamedValue) (driver.Result, error) {
+			if query != "SELECT 1" {
+				t.Errorf("got probe %q, want SELECT 1", query)
+			}
+			probes.Add(1)
+			if failing.Load() && c.ID == 1 {
+				return nil, errDeadConnection
+			}
+			return driver.RowsAffected(0), nil
+		},
+		Ping: func(_ context.Context, _ *fakedriver.Conn) error {
+			t.Error("got a ping, want the probe query")
+			return nil
+		},
+	}
+	p := newFakePool(t, d, &Config{
+		ValidateOnHealthCheck: true,
+		HealthCheckQuery:      "SELECT 1",
+		HealthCheckPeriod:     10 * time.Millisecond,
+	})
+	fillIdle(t, p, 2)
+
+	failing.Store(true)
+	eventually(t, func() bool { return p.Stat().ValidationDestroyCount() == 1 })
+	eventually(t, func() bool { return p.Stat().TotalConnections() == 1 })
+	if probes.Load() == 0 {
+		t.Fatal("got no probe")
+	}
+}
+
+func TestHealthCheckWithoutValidation(t *testing.T) {
+	var pings atomic.Int64
+	d := &fakedriver.Driver{Ping: func(_ context.Context, _ *fakedriver.Conn) error {
+		pings.Add(1)
+		return errDeadConnection
+	}}
+	p := newFakePool(t, d, &Config{HealthCheckPeriod: 10 * time.Millisecond})
+	fillIdle(t, p, 2)
+
+	time.Sleep(50 * time.Millisecond)
+	if s := p.Stat(); s.ValidationDestroyCount() != 0 || s.TotalConnections() != 2 || pings.Load() != 0 {
+		t.Fatalf("got %d destroyed out of %d after %d pings, want no probe", s.ValidationDestroyCount(),
+			s.TotalConnections(), pings.Load())
+	}
+}
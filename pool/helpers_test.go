@@ -0,0 +1,72 @@
+package pool
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	alphasql "github.com/sinhashubham95/alpha-sql"
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+)
+
+// fakeDrivers numbers the fake drivers registered, so a test may register several of them.
+var fakeDrivers atomic.Int64
+
+// newFakePool creates a Pool over the fake driver provided, registered under a name derived from the test.
+func newFakePool(t *testing.T, d *fakedriver.Driver, cfg *Config) *Pool {
+	t.Helper()
+	p, err := newFakePoolWithError(t, d, cfg)
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+	t.Cleanup(func() {
+		done := make(chan struct{})
+		go func() {
+			p.Close(context.Background())
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Error("close did not return, a connection is most likely still acquired")
+		}
+	})
+	return p
+}
+
+// newFakePoolWithError creates a Pool over the fake driver provided, returning the error of New.
+func newFakePoolWithError(t *testing.T, d *fakedriver.Driver, cfg *Config) (*Pool, error) {
+	t.Helper()
+	return New(context.Background(), fakeConfig(t, d, cfg))
+}
+
+// fakeConfig sets up cfg to connect through the fake driver provided, registered under a name derived from the test.
+func fakeConfig(t *testing.T, d *fakedriver.Driver, cfg *Config) *Config {
+	t.Helper()
+	if cfg == nil {
+		cfg = &Config{}
+	}
+	if cfg.ConnectionConfig == nil {
+		cfg.ConnectionConfig = &alphasql.ConnectionConfig{}
+	}
+	name := t.Name() + "#" + strconv.FormatInt(fakeDrivers.Add(1), 10)
+	cfg.ConnectionConfig.DriverName = name
+	cfg.ConnectionConfig.URL = "fake"
+	alphasql.RegisterDriver(name, d)
+	t.Cleanup(func() { alphasql.DeregisterDriver(name) })
+	return cfg
+}
+
+// eventually fails the test if condition does not hold within two seconds.
+func eventually(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
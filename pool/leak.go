@@ -0,0 +1,40 @@
+package pool
+
+import (
+	"runtime/debug"
+	"time"
+)
+
+// AcquireInfo describes where and when a Connection still held was acquired.
+type AcquireInfo struct {
+	ConnectionID uint64
+	AcquiredAt   time.Time
+	Duration     time.Duration
+	Stack        string
+}
+
+// LeakedConnections returns the connections acquired for longer than Config.LeakThreshold, along with the stack
+// of the caller that acquired them. It only reports connections when Config.TrackAcquireStacks is set.
+func (p *Pool) LeakedConnections() []AcquireInfo {
+	p.p.mu.Lock()
+	defer p.p.mu.Unlock()
+	now := time.Now()
+	var leaked []AcquireInfo
+	for _, c := range p.p.allConnections {
+		if c.status != connectionStatusAcquired || c.acquireStack == nil {
+			continue
+		}
+		if d := now.Sub(c.acquiredAt); d > p.leakThreshold {
+			leaked = append(leaked, AcquireInfo{ConnectionID: c.id, AcquiredAt: c.acquiredAt, Duration: d, Stack: string(c.acquireStack)})
+		}
+	}
+	return leaked
+}
+
+func (p *pool) recordAcquireStack(c *Connection) {
+	stack := debug.Stack()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	c.acquiredAt = time.Now()
+	c.acquireStack = stack
+}
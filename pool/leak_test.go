@@ -0,0 +1,62 @@
+package pool
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+)
+
+// acquireAndLeak acquires a Connection without releasing it, so that its name shows in the stack recorded.
+func acquireAndLeak(t *testing.T, p *Pool) *Connection {
+	t.Helper()
+	c, err := p.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	return c
+}
+
+func TestLeakedConnections(t *testing.T) {
+	p := newFakePool(t, &fakedriver.Driver{}, &Config{TrackAcquireStacks: true, LeakThreshold: 10 * time.Millisecond})
+	ctx := context.Background()
+
+	leaked := acquireAndLeak(t, p)
+	defer p.Release(ctx, leaked)
+	released := acquireAndLeak(t, p)
+	p.Release(ctx, released)
+
+	if l := p.LeakedConnections(); len(l) != 0 {
+		t.Fatalf("got %d leaked connections before the threshold, want 0", len(l))
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	l := p.LeakedConnections()
+	if len(l) != 1 {
+		t.Fatalf("got %d leaked connections, want 1", len(l))
+	}
+	if l[0].ConnectionID != leaked.ID() {
+		t.Fatalf("got connection %d leaked, want %d", l[0].ConnectionID, leaked.ID())
+	}
+	if l[0].Duration < 10*time.Millisecond || l[0].AcquiredAt.IsZero() {
+		t.Fatalf("got acquired at %v for %v, want at least the threshold", l[0].AcquiredAt, l[0].Duration)
+	}
+	if !strings.Contains(l[0].Stack, "acquireAndLeak") {
+		t.Fatalf("got stack %q, want it to include the caller acquiring the connection", l[0].Stack)
+	}
+}
+
+func TestLeakedConnectionsWithoutTracking(t *testing.T) {
+	p := newFakePool(t, &fakedriver.Driver{}, &Config{LeakThreshold: time.Millisecond})
+	ctx := context.Background()
+
+	c := acquireAndLeak(t, p)
+	defer p.Release(ctx, c)
+	time.Sleep(5 * time.Millisecond)
+
+	if l := p.LeakedConnections(); len(l) != 0 {
+		t.Fatalf("got %d leaked connections with the stacks not tracked, want 0", len(l))
+	}
+}
@@ -0,0 +1,44 @@
+package pool
+
+// mvQueue implements a multi-version queue.
+//
+// mvQueue works as common queue, popping the connections in the order they were
+// pushed, except for the fact that all elements in the older version are guaranteed
+// to be popped before any element in the newer version, same as mvStack.
+type mvQueue struct {
+	old []*Connection
+	new []*Connection
+}
+
+func newMVQueue() *mvQueue {
+	return &mvQueue{}
+}
+
+func (q *mvQueue) pop() (*Connection, bool) {
+	if len(q.old) == 0 {
+		q.old, q.new = q.new, q.old[:0]
+	}
+	if len(q.old) == 0 {
+		return nil, false
+	}
+	c := q.old[0]
+	q.old[0] = nil // Avoid memory leak
+	q.old = q.old[1:]
+	return c, true
+}
+
+func (q *mvQueue) push(c *Connection) {
+	q.new = append(q.new, c)
+}
+
+func (q *mvQueue) bump() {
+	q.old = append(q.old, q.new...)
+	for i := range q.new {
+		q.new[i] = nil // Avoid memory leak
+	}
+	q.new = q.new[:0]
+}
+
+func (q *mvQueue) length() int {
+	return len(q.old) + len(q.new)
+}
@@ -2,6 +2,14 @@ package pool
 
 import "github.com/sinhashubham95/go-utils/structures/stack"
 
+// idleStore is used to hold the idle connections of the pool, deciding the order in which they are reused.
+type idleStore interface {
+	pop() (*Connection, bool)
+	push(c *Connection)
+	bump()
+	length() int
+}
+
 // mvStack implements a multi-version stack.
 //
 // mvStack works as common stack except for the fact that all elements in the
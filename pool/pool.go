@@ -3,6 +3,7 @@ package pool
 import (
 	"context"
 	"errors"
+	"fmt"
 	alphasql "github.com/sinhashubham95/alpha-sql"
 	"golang.org/x/sync/semaphore"
 	"sync"
@@ -24,20 +25,41 @@ type pool struct {
 	destructWG sync.WaitGroup
 
 	allConnections  []*Connection
-	idleConnections *mvStack
+	idleConnections idleStore
+
+	// lastConnectionID is the id assigned to the last Connection created.
+	lastConnectionID atomic.Uint64
 
 	maxSize int32
 
+	// resizeMu guards the permits of acquireSem reserved to enforce maxSize, the semaphore being created with the
+	// capacity of maxSemaphoreSize, which cannot be changed afterwards. reservedPermits is the number of permits held,
+	// and targetReservedPermits the number to hold for the current maxSize, reserving being set while the permits
+	// missing are being waited for in the background. The reserved permits are released holding resizeMu instead
+	// of mu, as they are not tied to any Connection.
+	resizeMu              sync.Mutex
+	reservedPermits       int64
+	targetReservedPermits int64
+	reserving             bool
+
 	constructor func(ctx context.Context) (*alphasql.Connection, error)
 	destructor  func(ctx context.Context, c *alphasql.Connection) error
 
 	acquireCount         int64
 	acquireDuration      time.Duration
 	emptyAcquireCount    int64
+	idleAcquireCount     int64
 	canceledAcquireCount atomic.Int64
 
 	resetCount int
 
+	// afterReleaseDestroyReasons counts the connections destroyed because of AfterRelease by their reasons.
+	afterReleaseDestroyReasons map[string]int64
+
+	// generation is bumped every time the connections are to be recycled, and connections created in an
+	// older generation are treated as expired.
+	generation atomic.Int64
+
 	baseAcquireCtx       context.Context
 	cancelBaseAcquireCtx context.CancelFunc
 	closed               bool
@@ -47,9 +69,13 @@ type pool struct {
 type Pool struct {
 	// 64 bit fields accessed with atomics must be at beginning of struct to guarantee alignment for certain 32-bit
 	// architectures. See BUGS section of https://pkg.go.dev/sync/atomic and https://github.com/jackc/pgx/issues/1288.
-	newConnectionsCount  atomic.Int64
-	lifetimeDestroyCount atomic.Int64
-	idleDestroyCount     atomic.Int64
+	newConnectionsCount      atomic.Int64
+	lifetimeDestroyCount     atomic.Int64
+	resetDestroyCount        atomic.Int64
+	idleDestroyCount         atomic.Int64
+	validationDestroyCount   atomic.Int64
+	afterReleaseDestroyCount atomic.Int64
+	acquireRejectedCount     atomic.Int64
 
 	p                           *pool
 	db                          *alphasql.DB
@@ -57,14 +83,26 @@ type Pool struct {
 	beforeConnect               func(context.Context, *alphasql.ConnectionConfig) error
 	afterConnect                func(context.Context, *alphasql.Connection) error
 	beforeAcquire               func(context.Context, *Connection) bool
+	onAcquire                   func(context.Context, *Connection) error
+	onAcquireRejected           func(context.Context)
 	afterRelease                func(context.Context, *Connection) bool
+	afterReleaseDestroyReason   func(context.Context, *Connection) string
 	beforeClose                 func(context.Context, *alphasql.Connection)
+	onDrainComplete             func()
+	shouldDestroyOnError        func(error) bool
 	minConnections              int32
 	maxConnections              int32
 	maxConnectionLifetime       time.Duration
 	maxConnectionLifetimeJitter time.Duration
 	maxConnectionIdleTime       time.Duration
 	healthCheckPeriod           time.Duration
+	validateOnHealthCheck       bool
+	healthCheckQuery            string
+	pinPreparedStatements       bool
+	idleSelectionPolicy         IdleSelectionPolicy
+	trackAcquireStacks          bool
+	leakThreshold               time.Duration
+	acquireTimeout              time.Duration
 
 	healthCheckChan chan struct{}
 
@@ -88,36 +126,105 @@ func New(ctx context.Context, cfg *Config) (*Pool, error) {
 		beforeConnect:               cfg.BeforeConnect,
 		afterConnect:                cfg.AfterConnect,
 		beforeAcquire:               cfg.BeforeAcquire,
+		onAcquire:                   cfg.OnAcquire,
+		onAcquireRejected:           cfg.OnAcquireRejected,
 		afterRelease:                cfg.AfterRelease,
+		afterReleaseDestroyReason:   cfg.AfterReleaseDestroyReason,
 		beforeClose:                 cfg.BeforeClose,
+		onDrainComplete:             cfg.OnDrainComplete,
+		shouldDestroyOnError:        cfg.ShouldDestroyOnError,
 		minConnections:              cfg.MinConnections,
 		maxConnections:              cfg.MaxConnections,
 		maxConnectionLifetime:       cfg.MaxConnectionLifetime,
 		maxConnectionLifetimeJitter: cfg.MaxConnectionLifetimeJitter,
 		maxConnectionIdleTime:       cfg.MaxConnectionIdleTime,
 		healthCheckPeriod:           cfg.HealthCheckPeriod,
+		validateOnHealthCheck:       cfg.ValidateOnHealthCheck,
+		healthCheckQuery:            cfg.HealthCheckQuery,
+		pinPreparedStatements:       cfg.PinPreparedStatements,
+		idleSelectionPolicy:         cfg.IdleSelectionPolicy,
+		trackAcquireStacks:          cfg.TrackAcquireStacks,
+		leakThreshold:               cfg.LeakThreshold,
+		acquireTimeout:              cfg.AcquireTimeout,
 		healthCheckChan:             make(chan struct{}, 1),
 		closeChan:                   make(chan struct{}),
 	}
 	p := newPool(ctx, pp)
 	pp.p = p
-	go pp.warmup(ctx)
+	if !cfg.SynchronousWarmup {
+		go pp.warmup(ctx)
+		return pp, nil
+	}
+	err = pp.createIdleConnections(ctx, int(pp.minConnections))
+	if cfg.RequireMinConnectionsAtStartup {
+		if err != nil {
+			err = fmt.Errorf("%w: %w", alphasql.ErrMinConnectionsNotMet, err)
+		} else if p.getTotalConnections() < int(pp.minConnections) {
+			err = alphasql.ErrMinConnectionsNotMet
+		}
+	}
+	if err != nil {
+		pp.Close(ctx)
+		_ = db.Close()
+		return nil, err
+	}
+	go pp.healthChecker(ctx)
 	return pp, nil
 }
 
+// Config returns a copy of the effective configuration of the pool, including the defaults resolved when it was
+// created and the maximum size set by [Pool.SetMaxConnections]. Modifying the copy does not affect the pool.
+func (p *Pool) Config() *Config {
+	c := p.config.Copy()
+	c.MaxConnections = p.p.getMaxSize()
+	return c
+}
+
 // Close closes all connections in the pool and rejects future Acquire calls. Blocks until all connections are returned.
 func (p *Pool) Close(ctx context.Context) {
 	p.closeOnce.Do(func() {
 		close(p.closeChan)
 		p.p.close(ctx)
+		p.onDrainComplete()
 	})
 }
 
+// RotateCredentials gradually recycles all the connections in the pool, so that they are replaced by connections
+// established after [Config.BeforeConnect] has been evaluated again. Idle connections are replaced by the health check
+// while respecting [Config.MinConnections], and acquired connections are destroyed once released, so no in-flight
+// query is interrupted.
+func (p *Pool) RotateCredentials(_ context.Context) {
+	p.p.generation.Add(1)
+	select {
+	case p.healthCheckChan <- struct{}{}:
+	default:
+	}
+}
+
+// Reset recycles all the connections in the pool at once, for instance after a failover where every existing
+// server side session is stale. Idle connections are destroyed right away, and acquired connections are destroyed
+// once released instead of being returned to the pool, so Reset does not block on them. The health check then
+// refills the pool up to [Config.MinConnections].
+func (p *Pool) Reset(ctx context.Context) {
+	p.p.mu.Lock()
+	p.p.resetCount++
+	p.p.generation.Add(1)
+	p.p.mu.Unlock()
+	for _, c := range p.p.acquireAllIdleConnections() {
+		p.resetDestroyCount.Add(1)
+		go p.p.destroyAcquiredConnection(ctx, c)
+	}
+	select {
+	case p.healthCheckChan <- struct{}{}:
+	default:
+	}
+}
+
 func newPool(ctx context.Context, p *Pool) *pool {
 	baseAcquireCtx, cancelBaseAcquireCtx := context.WithCancel(ctx)
-	return &pool{
-		acquireSem:           semaphore.NewWeighted(int64(p.maxConnections)),
-		idleConnections:      newMVStack(),
+	pp := &pool{
+		acquireSem:           semaphore.NewWeighted(maxSemaphoreSize),
+		idleConnections:      newIdleStore(p.idleSelectionPolicy),
 		allConnections:       make([]*Connection, 0),
 		maxSize:              p.maxConnections,
 		constructor:          p.constructor,
@@ -125,6 +232,17 @@ func newPool(ctx context.Context, p *Pool) *pool {
 		baseAcquireCtx:       baseAcquireCtx,
 		cancelBaseAcquireCtx: cancelBaseAcquireCtx,
 	}
+	pp.reservedPermits = maxSemaphoreSize - int64(p.maxConnections)
+	pp.targetReservedPermits = pp.reservedPermits
+	pp.acquireSem.TryAcquire(pp.reservedPermits)
+	return pp
+}
+
+func newIdleStore(policy IdleSelectionPolicy) idleStore {
+	if policy == IdleSelectionPolicyFIFO {
+		return newMVQueue()
+	}
+	return newMVStack()
 }
 
 func (p *pool) createConnection(ctx context.Context, maxConnectionLifetime, maxConnectionLifetimeJitter time.Duration) error {
@@ -173,6 +291,10 @@ func (p *Pool) createIdleConnection(ctx context.Context, errs chan error) {
 }
 
 func (p *Pool) createIdleConnections(ctx context.Context, count int) error {
+	// the health check asks for the connections missing to reach MinConnections, which is negative above it
+	if count <= 0 {
+		return nil
+	}
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 	errs := make(chan error, count)
@@ -199,10 +321,11 @@ func (p *pool) getTotalConnections() int {
 func (p *Pool) handleExpiryIdlenessForConnections(ctx context.Context) bool {
 	destroyed := false
 	total := p.p.getTotalConnections()
+	maxSize := p.p.getMaxSize()
 	idleConnections := p.p.acquireAllIdleConnections()
 	for _, c := range idleConnections {
 		if p.isExpiredConnection(c) && total >= int(p.minConnections) {
-			p.lifetimeDestroyCount.Add(1)
+			p.countExpiredConnectionDestroy(c)
 			go p.p.destroyAcquiredConnection(ctx, c)
 			total--
 			destroyed = true
@@ -211,6 +334,17 @@ func (p *Pool) handleExpiryIdlenessForConnections(ctx context.Context) bool {
 			go p.p.destroyAcquiredConnection(ctx, c)
 			total--
 			destroyed = true
+		} else if total > int(maxSize) && total > int(p.minConnections) {
+			// surplus Connection left after the maximum size of the pool was lowered
+			p.idleDestroyCount.Add(1)
+			go p.p.destroyAcquiredConnection(ctx, c)
+			total--
+			destroyed = true
+		} else if p.validateOnHealthCheck && p.probeConnection(ctx, c) != nil {
+			p.validationDestroyCount.Add(1)
+			go p.p.destroyAcquiredConnection(ctx, c)
+			total--
+			destroyed = true
 		} else {
 			p.p.releaseUnused(ctx, c)
 		}
@@ -218,6 +352,14 @@ func (p *Pool) handleExpiryIdlenessForConnections(ctx context.Context) bool {
 	return destroyed
 }
 
+func (p *Pool) probeConnection(ctx context.Context, c *Connection) error {
+	if p.healthCheckQuery == "" {
+		return c.Ping(ctx)
+	}
+	_, err := c.Exec(ctx, p.healthCheckQuery)
+	return err
+}
+
 func (p *Pool) checkHealthForConnections(ctx context.Context) {
 	for {
 		if err := p.createIdleConnections(ctx, int(p.minConnections)-p.p.getTotalConnections()); err != nil {
@@ -275,7 +417,7 @@ func (p *pool) close(ctx context.Context) {
 	p.closed = true
 	p.cancelBaseAcquireCtx()
 
-	for c, ok := p.idleConnections.pop(); ok; {
+	for c, ok := p.idleConnections.pop(); ok; c, ok = p.idleConnections.pop() {
 		removeFromConnections(&p.allConnections, c)
 		go p.destroyConnection(ctx, c)
 	}
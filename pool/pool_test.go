@@ -0,0 +1,186 @@
+package pool
+
+import (
+	"context"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	alphasql "github.com/sinhashubham95/alpha-sql"
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+)
+
+// connectionNames returns the sorted names the connections of the pool were established with.
+func connectionNames(p *Pool) []string {
+	p.p.mu.Lock()
+	defer p.p.mu.Unlock()
+	var names []string
+	for _, c := range p.p.allConnections {
+		if c.status != connectionStatusInitialising {
+			names = append(names, c.c.Connection().(*fakedriver.Conn).Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func equalNames(got []string, want ...string) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestRotateCredentials(t *testing.T) {
+	var credential atomic.Value
+	credential.Store("old")
+	p := newFakePool(t, &fakedriver.Driver{}, &Config{
+		BeforeConnect: func(_ context.Context, cfg *alphasql.ConnectionConfig) error {
+			cfg.URL = credential.Load().(string)
+			return nil
+		},
+		MinConnections:    2,
+		MaxConnections:    4,
+		SynchronousWarmup: true,
+		HealthCheckPeriod: 10 * time.Millisecond,
+	})
+	ctx := context.Background()
+	if names := connectionNames(p); !equalNames(names, "old", "old") {
+		t.Fatalf("got connections %v, want 2 old ones", names)
+	}
+
+	inFlight, err := p.Acquire(ctx)
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	credential.Store("new")
+	p.RotateCredentials(ctx)
+
+	// the idle connections are replaced, while the acquired one is kept until it is released
+	eventually(t, func() bool { return equalNames(connectionNames(p), "new", "old") })
+	if err = inFlight.Ping(ctx); err != nil {
+		t.Fatalf("ping on the in-flight connection: %v", err)
+	}
+	if name := inFlight.c.Connection().(*fakedriver.Conn).Name; name != "old" {
+		t.Fatalf("got the in-flight connection established with %q, want old", name)
+	}
+	p.Release(ctx, inFlight)
+	eventually(t, func() bool { return equalNames(connectionNames(p), "new", "new") })
+}
+
+func TestIdleSelectionPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		policy IdleSelectionPolicy
+		want   []int
+	}{
+		{policy: IdleSelectionPolicyLIFO, want: []int{2, 1, 0}},
+		{policy: IdleSelectionPolicyFIFO, want: []int{0, 1, 2}},
+	} {
+		t.Run(string(tc.policy), func(t *testing.T) {
+			p := newFakePool(t, &fakedriver.Driver{}, &Config{IdleSelectionPolicy: tc.policy})
+			ctx := context.Background()
+			released := make([]uint64, 3)
+			cs := make([]*Connection, 3)
+			for i := range cs {
+				c, err := p.Acquire(ctx)
+				if err != nil {
+					t.Fatalf("acquire: %v", err)
+				}
+				cs[i] = c
+			}
+			for i, c := range cs {
+				released[i] = c.ID()
+				p.Release(ctx, c)
+				eventually(t, func() bool { return p.Stat().IdleConnections() == int32(i+1) })
+			}
+			for j, i := range tc.want {
+				c, err := p.Acquire(ctx)
+				if err != nil {
+					t.Fatalf("acquire: %v", err)
+				}
+				cs[j] = c
+				if c.ID() != released[i] {
+					t.Errorf("got connection %d, want connection %d released in position %d", c.ID(), released[i], i)
+				}
+			}
+			for _, c := range cs {
+				p.Release(ctx, c)
+			}
+		})
+	}
+}
+
+// connectionIDs returns the ids the driver assigned to the connections of the pool.
+func connectionIDs(p *Pool) []int64 {
+	p.p.mu.Lock()
+	defer p.p.mu.Unlock()
+	var ids []int64
+	for _, c := range p.p.allConnections {
+		if c.status != connectionStatusInitialising {
+			ids = append(ids, c.c.Connection().(*fakedriver.Conn).ID)
+		}
+	}
+	return ids
+}
+
+func TestReset(t *testing.T) {
+	d := &fakedriver.Driver{}
+	p := newFakePool(t, d, &Config{
+		MinConnections:    2,
+		MaxConnections:    4,
+		SynchronousWarmup: true,
+		HealthCheckPeriod: 10 * time.Millisecond,
+	})
+	ctx := context.Background()
+	inFlight, err := p.Acquire(ctx)
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+
+	// Reset must not block on the acquired connection
+	done := make(chan struct{})
+	go func() {
+		p.Reset(ctx)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("reset blocked on the acquired connection")
+	}
+	if n := p.Stat().ResetCount(); n != 1 {
+		t.Fatalf("got %d resets, want 1", n)
+	}
+
+	// the idle connection is replaced by the health check, while the acquired one is kept until it is released
+	eventually(t, func() bool {
+		ids := connectionIDs(p)
+		return len(ids) == 2 && d.Closes.Load() == 1 && p.Stat().ResetDestroyCount() == 1
+	})
+	if err = inFlight.Ping(ctx); err != nil {
+		t.Fatalf("ping on the in-flight connection: %v", err)
+	}
+
+	p.Release(ctx, inFlight)
+	eventually(t, func() bool {
+		for _, id := range connectionIDs(p) {
+			if id <= 2 {
+				return false
+			}
+		}
+		return len(connectionIDs(p)) == 2 && d.Closes.Load() == 2
+	})
+	s := p.Stat()
+	if n := s.ResetDestroyCount(); n != 2 {
+		t.Fatalf("got %d connections destroyed by the reset, want 2", n)
+	}
+	if n := s.LifetimeDestroyCount(); n != 0 {
+		t.Fatalf("got %d connections destroyed for their lifetime, want 0", n)
+	}
+}
@@ -0,0 +1,82 @@
+package pool
+
+import (
+	"context"
+	alphasql "github.com/sinhashubham95/alpha-sql"
+	"math"
+)
+
+// maxSemaphoreSize is the capacity acquireSem is created with. As a semaphore.Weighted cannot be resized, the maximum
+// size of the pool is enforced by holding the permits beyond it, the ones reserved, instead of swapping in a new
+// semaphore, which the permits held by the acquired connections would be released to.
+const maxSemaphoreSize = math.MaxInt32
+
+// SetMaxConnections changes the maximum size of the pool while it is in use. Growing it allows more connections to
+// be acquired right away. Shrinking it prevents acquiring more connections than the new maximum, the acquisitions
+// beyond it waiting for the connections to be released, while the surplus idle connections are destroyed by the
+// health check. It returns alphasql.ErrInvalidMaxConnections if n is not positive or is below MinConnections.
+func (p *Pool) SetMaxConnections(n int32) error {
+	if n <= 0 || n < p.minConnections {
+		return alphasql.ErrInvalidMaxConnections
+	}
+	p.p.setMaxSize(n)
+	p.forceTriggerHealthCheck()
+	return nil
+}
+
+func (p *pool) getMaxSize() int32 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.maxSize
+}
+
+func (p *pool) setMaxSize(n int32) {
+	p.resizeMu.Lock()
+	p.targetReservedPermits = maxSemaphoreSize - int64(n)
+	if p.reservedPermits > p.targetReservedPermits {
+		p.acquireSem.Release(p.reservedPermits - p.targetReservedPermits)
+		p.reservedPermits = p.targetReservedPermits
+	}
+	// take the permits free right away, and wait for the ones held by the acquired connections in the background
+	for p.reservedPermits < p.targetReservedPermits && p.acquireSem.TryAcquire(1) {
+		p.reservedPermits++
+	}
+	if p.reservedPermits < p.targetReservedPermits && !p.reserving {
+		p.reserving = true
+		go p.reservePermits(p.baseAcquireCtx)
+	}
+	p.resizeMu.Unlock()
+
+	p.mu.Lock()
+	p.maxSize = n
+	p.mu.Unlock()
+}
+
+// reservePermits waits for the permits missing to enforce the maximum size of the pool, one at a time, so the
+// acquisitions are not blocked behind a single large request. It stops once the pool is closed.
+func (p *pool) reservePermits(ctx context.Context) {
+	for {
+		p.resizeMu.Lock()
+		if p.reservedPermits >= p.targetReservedPermits {
+			p.reserving = false
+			p.resizeMu.Unlock()
+			return
+		}
+		p.resizeMu.Unlock()
+
+		err := p.acquireSem.Acquire(ctx, 1)
+
+		p.resizeMu.Lock()
+		if err != nil {
+			p.reserving = false
+			p.resizeMu.Unlock()
+			return
+		}
+		if p.reservedPermits < p.targetReservedPermits {
+			p.reservedPermits++
+		} else {
+			p.acquireSem.Release(1)
+		}
+		p.resizeMu.Unlock()
+	}
+}
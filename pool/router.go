@@ -0,0 +1,129 @@
+package pool
+
+import (
+	"context"
+	"fmt"
+	alphasql "github.com/sinhashubham95/alpha-sql"
+)
+
+type databaseKey struct{}
+
+// WithDatabase returns a copy of the context carrying the logical name of the database, used by the [Router]
+// to select the [Pool] the operations run with the context are routed to.
+func WithDatabase(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, databaseKey{}, name)
+}
+
+// Database returns the logical name of the database set on the context with [WithDatabase], if any.
+func Database(ctx context.Context) string {
+	name, _ := ctx.Value(databaseKey{}).(string)
+	return name
+}
+
+// RouterConfig is the configuration required for creating a router.
+type RouterConfig struct {
+	// Databases is the configuration of the pool of each logical database, keyed by its name.
+	Databases map[string]*Config
+
+	// DefaultDatabase is the name of the database used when none is set on the context.
+	// If it is empty, the operations run without a database set on the context fail with
+	// [alphasql.ErrUnknownDatabase].
+	DefaultDatabase string
+}
+
+// Router is used to route the operations to the [Pool] of the logical database set on the context
+// with [WithDatabase], for the applications talking to several databases through one abstraction.
+type Router struct {
+	pools           map[string]*Pool
+	defaultDatabase string
+}
+
+// NewRouter is used to create a new router, creating the pool of each of the databases configured.
+func NewRouter(ctx context.Context, cfg *RouterConfig) (*Router, error) {
+	if cfg.DefaultDatabase != "" {
+		if _, ok := cfg.Databases[cfg.DefaultDatabase]; !ok {
+			return nil, fmt.Errorf("%w: %q", alphasql.ErrUnknownDatabase, cfg.DefaultDatabase)
+		}
+	}
+	r := &Router{
+		pools:           make(map[string]*Pool, len(cfg.Databases)),
+		defaultDatabase: cfg.DefaultDatabase,
+	}
+	for name, c := range cfg.Databases {
+		p, err := New(ctx, c)
+		if err != nil {
+			r.Close(ctx)
+			return nil, err
+		}
+		r.pools[name] = p
+	}
+	return r, nil
+}
+
+// Pool returns the pool of the database set on the context, or of the default database if none is set.
+// If the database is not configured, [alphasql.ErrUnknownDatabase] is returned.
+func (r *Router) Pool(ctx context.Context) (*Pool, error) {
+	name := Database(ctx)
+	if name == "" {
+		name = r.defaultDatabase
+	}
+	p, ok := r.pools[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", alphasql.ErrUnknownDatabase, name)
+	}
+	return p, nil
+}
+
+// Query executes a query that returns rows on the database set on the context. See [Pool.Query] for details.
+func (r *Router) Query(ctx context.Context, query string, args ...any) (alphasql.Rows, error) {
+	p, err := r.Pool(ctx)
+	if err != nil {
+		return &poolErrRows{err: err}, err
+	}
+	return p.Query(ctx, query, args...)
+}
+
+// QueryRow executes a query that is expected to return at most one row on the database set on the context.
+// See [Pool.QueryRow] for details.
+func (r *Router) QueryRow(ctx context.Context, query string, args ...any) alphasql.Row {
+	p, err := r.Pool(ctx)
+	if err != nil {
+		return &poolErrRow{err: err}
+	}
+	return p.QueryRow(ctx, query, args...)
+}
+
+// Exec executes a query without returning any rows on the database set on the context.
+// See [Pool.Exec] for details.
+func (r *Router) Exec(ctx context.Context, query string, args ...any) (alphasql.Result, error) {
+	p, err := r.Pool(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return p.Exec(ctx, query, args...)
+}
+
+// Prepare creates a prepared statement on the database set on the context. See [Pool.Prepare] for details.
+func (r *Router) Prepare(ctx context.Context, query string) (alphasql.Statement, error) {
+	p, err := r.Pool(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return p.Prepare(ctx, query)
+}
+
+// BeginTX starts a transaction on the database set on the context. See [Pool.BeginTX] for details.
+func (r *Router) BeginTX(ctx context.Context, options *alphasql.TXOptions) (alphasql.TX, error) {
+	p, err := r.Pool(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return p.BeginTX(ctx, options)
+}
+
+// Close closes the pools of all the databases.
+func (r *Router) Close(ctx context.Context) {
+	for _, p := range r.pools {
+		p.Close(ctx)
+	}
+}
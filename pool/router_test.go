@@ -0,0 +1,126 @@
+package pool
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	alphasql "github.com/sinhashubham95/alpha-sql"
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+)
+
+// databaseDriver returns a fake driver answering every query with the name of its database.
+func databaseDriver(name string) *fakedriver.Driver {
+	return &fakedriver.Driver{
+		Query: func(_ context.Context, _ *fakedriver.Conn, _ string, _ []driver.NamedValue) (driver.Rows, error) {
+			return fakedriver.NewRows([]string{"current_database"}, []driver.Value{name}), nil
+		},
+	}
+}
+
+func newFakeRouter(t *testing.T, defaultDatabase string) *Router {
+	t.Helper()
+	r, err := NewRouter(context.Background(), &RouterConfig{
+		Databases: map[string]*Config{
+			"users":  fakeConfig(t, databaseDriver("users"), nil),
+			"orders": fakeConfig(t, databaseDriver("orders"), nil),
+		},
+		DefaultDatabase: defaultDatabase,
+	})
+	if err != nil {
+		t.Fatalf("new router: %v", err)
+	}
+	t.Cleanup(func() {
+		done := make(chan struct{})
+		go func() {
+			r.Close(context.Background())
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Error("close did not return, a connection is most likely still acquired")
+		}
+	})
+	return r
+}
+
+func currentDatabase(ctx context.Context, r *Router) (string, error) {
+	var name string
+	err := r.QueryRow(ctx, "SELECT current_database()").Scan(ctx, &name)
+	return name, err
+}
+
+func TestRouter(t *testing.T) {
+	r := newFakeRouter(t, "users")
+	for _, tc := range []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{"users", WithDatabase(context.Background(), "users"), "users"},
+		{"orders", WithDatabase(context.Background(), "orders"), "orders"},
+		{"default", context.Background(), "users"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := currentDatabase(tc.ctx, r)
+			if err != nil {
+				t.Fatalf("query row: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("got routed to %q, want %q", got, tc.want)
+			}
+
+			rows, err := r.Query(tc.ctx, "SELECT current_database()")
+			if err != nil {
+				t.Fatalf("query: %v", err)
+			}
+			defer func() { _ = rows.Close(tc.ctx) }()
+			if !rows.Next(tc.ctx) {
+				t.Fatalf("next: %v", rows.Error())
+			}
+			if err = rows.Scan(&got); err != nil || got != tc.want {
+				t.Fatalf("got routed to %q, %v, want %q", got, err, tc.want)
+			}
+		})
+	}
+}
+
+func TestRouterUnknownDatabase(t *testing.T) {
+	r := newFakeRouter(t, "")
+	for _, tc := range []struct {
+		name string
+		ctx  context.Context
+	}{
+		{"unknown", WithDatabase(context.Background(), "billing")},
+		{"no default", context.Background()},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := currentDatabase(tc.ctx, r); !errors.Is(err, alphasql.ErrUnknownDatabase) {
+				t.Fatalf("got %v from query row, want %v", err, alphasql.ErrUnknownDatabase)
+			}
+			rows, err := r.Query(tc.ctx, "SELECT 1")
+			if !errors.Is(err, alphasql.ErrUnknownDatabase) {
+				t.Fatalf("got %v from query, want %v", err, alphasql.ErrUnknownDatabase)
+			}
+			if err = rows.Scan(); !errors.Is(err, alphasql.ErrUnknownDatabase) {
+				t.Fatalf("got %v scanning the rows returned, want %v", err, alphasql.ErrUnknownDatabase)
+			}
+			if _, err = r.Exec(tc.ctx, "DELETE FROM users"); !errors.Is(err, alphasql.ErrUnknownDatabase) {
+				t.Fatalf("got %v from exec, want %v", err, alphasql.ErrUnknownDatabase)
+			}
+			if _, err = r.BeginTX(tc.ctx, nil); !errors.Is(err, alphasql.ErrUnknownDatabase) {
+				t.Fatalf("got %v from begin, want %v", err, alphasql.ErrUnknownDatabase)
+			}
+		})
+	}
+}
+
+func TestNewRouterUnknownDefaultDatabase(t *testing.T) {
+	_, err := NewRouter(context.Background(), &RouterConfig{DefaultDatabase: "users"})
+	if !errors.Is(err, alphasql.ErrUnknownDatabase) {
+		t.Fatalf("got %v, want %v", err, alphasql.ErrUnknownDatabase)
+	}
+}
@@ -8,6 +8,7 @@ import (
 type poolRow struct {
 	c *Connection
 	p *Pool
+	s alphasql.Statement
 	r alphasql.Row
 }
 
@@ -19,15 +20,13 @@ func (p *poolRow) Scan(ctx context.Context, values ...any) error {
 	var err error
 	panicked := true
 	defer func() {
-		if panicked && p.c != nil {
-			p.p.closeOrRelease(ctx, p.c, err)
+		if panicked {
+			p.release(ctx, err)
 		}
 	}()
 	err = p.r.Scan(ctx, values...)
 	panicked = false
-	if p.c != nil {
-		p.p.closeOrRelease(ctx, p.c, err)
-	}
+	p.release(ctx, err)
 	return err
 }
 
@@ -39,8 +38,19 @@ func (p *poolRow) Columns() []alphasql.Column {
 	return p.r.Columns()
 }
 
+func (p *poolRow) release(ctx context.Context, err error) {
+	if p.s != nil {
+		_ = p.s.Close(ctx)
+		p.s = nil
+	}
+	if p.c != nil {
+		p.p.closeOrRelease(ctx, p.c, err)
+		p.c = nil
+	}
+}
+
 func (p *poolErrRow) Scan(_ context.Context, _ ...any) error {
-	return nil
+	return p.err
 }
 
 func (p *poolErrRow) Error() error {
@@ -2,12 +2,14 @@ package pool
 
 import (
 	"context"
+	"errors"
 	alphasql "github.com/sinhashubham95/alpha-sql"
 )
 
 type poolRows struct {
 	c    *Connection
 	p    *Pool
+	s    alphasql.Statement
 	rows alphasql.Rows
 }
 
@@ -20,7 +22,11 @@ func (p *poolRows) Next(ctx context.Context) bool {
 }
 
 func (p *poolRows) NextResultSet(ctx context.Context) bool {
-	return p.rows.Next(ctx)
+	return p.rows.NextResultSet(ctx)
+}
+
+func (p *poolRows) HasNextResultSet() bool {
+	return p.rows.HasNextResultSet()
 }
 
 func (p *poolRows) Error() error {
@@ -29,8 +35,12 @@ func (p *poolRows) Error() error {
 
 func (p *poolRows) Close(ctx context.Context) error {
 	err := p.rows.Close(ctx)
+	if p.s != nil {
+		_ = p.s.Close(ctx)
+		p.s = nil
+	}
 	if p.c != nil {
-		p.p.Release(ctx, p.c)
+		p.p.closeOrRelease(ctx, p.c, errors.Join(p.rows.Error(), err))
 		p.c = nil
 	}
 	return err
@@ -40,10 +50,27 @@ func (p *poolRows) Scan(values ...any) error {
 	return p.rows.Scan(values...)
 }
 
+func (p *poolRows) Values() ([]any, error) {
+	return p.rows.Values()
+}
+
+func (p *poolRows) RawValues() [][]byte {
+	return p.rows.RawValues()
+}
+
 func (p *poolRows) Columns() []alphasql.Column {
 	return p.rows.Columns()
 }
 
+func (p *poolRows) DecodeInto(dest any) error {
+	return p.rows.DecodeInto(dest)
+}
+
+func (p *poolRows) ScanAll(ctx context.Context) ([][]any, []string, error) {
+	defer func() { _ = p.Close(ctx) }()
+	return p.rows.ScanAll(ctx)
+}
+
 func (p *poolErrRows) Next(_ context.Context) bool {
 	return false
 }
@@ -52,6 +79,10 @@ func (p *poolErrRows) NextResultSet(_ context.Context) bool {
 	return false
 }
 
+func (p *poolErrRows) HasNextResultSet() bool {
+	return false
+}
+
 func (p *poolErrRows) Error() error {
 	return p.err
 }
@@ -61,9 +92,25 @@ func (p *poolErrRows) Close(_ context.Context) error {
 }
 
 func (p *poolErrRows) Scan(_ ...any) error {
-	return nil
+	return p.err
 }
 
 func (p *poolErrRows) Columns() []alphasql.Column {
 	return nil
 }
+
+func (p *poolErrRows) DecodeInto(_ any) error {
+	return p.err
+}
+
+func (p *poolErrRows) ScanAll(_ context.Context) ([][]any, []string, error) {
+	return nil, nil, p.err
+}
+
+func (p *poolErrRows) Values() ([]any, error) {
+	return nil, p.err
+}
+
+func (p *poolErrRows) RawValues() [][]byte {
+	return nil
+}
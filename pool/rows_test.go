@@ -0,0 +1,137 @@
+package pool
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	alphasql "github.com/sinhashubham95/alpha-sql"
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+)
+
+// twoResultSetsDriver returns a fake driver answering every query with a result set of ids followed by one of names.
+func twoResultSetsDriver() *fakedriver.Driver {
+	return &fakedriver.Driver{
+		Query: func(_ context.Context, _ *fakedriver.Conn, _ string, _ []driver.NamedValue) (driver.Rows, error) {
+			return &fakedriver.Rows{Sets: []fakedriver.ResultSet{
+				{Columns: []string{"id"}, Rows: [][]driver.Value{{int64(1)}, {int64(2)}}},
+				{Columns: []string{"name"}, Rows: [][]driver.Value{{"a"}}},
+			}}, nil
+		},
+	}
+}
+
+func TestRowsNextResultSet(t *testing.T) {
+	p := newFakePool(t, twoResultSetsDriver(), nil)
+	ctx := context.Background()
+	r, err := p.Query(ctx, "CALL get_users()")
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	defer func() { _ = r.Close(ctx) }()
+
+	var ids []int64
+	for r.Next(ctx) {
+		var id int64
+		if err := r.Scan(&id); err != nil {
+			t.Fatalf("scan id: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Fatalf("got ids %v, want [1 2]", ids)
+	}
+
+	if !r.NextResultSet(ctx) {
+		t.Fatalf("got no second result set: %v", r.Error())
+	}
+	if cs := r.Columns(); len(cs) != 1 || cs[0].Name() != "name" {
+		t.Fatalf("got columns %v, want the name column of the second result set", cs)
+	}
+	if !r.Next(ctx) {
+		t.Fatalf("next: %v", r.Error())
+	}
+	var name string
+	if err := r.Scan(&name); err != nil {
+		t.Fatalf("scan name: %v", err)
+	}
+	if name != "a" {
+		t.Fatalf("got name %q, want a", name)
+	}
+	if r.Next(ctx) || r.NextResultSet(ctx) {
+		t.Fatal("got more rows or result sets, want the rows exhausted")
+	}
+	if err := r.Error(); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+}
+
+func TestRowsHasNextResultSet(t *testing.T) {
+	for _, prefetch := range []int{0, 2} {
+		cfg := &Config{ConnectionConfig: &alphasql.ConnectionConfig{RowPrefetch: prefetch}}
+		p := newFakePool(t, twoResultSetsDriver(), cfg)
+		ctx := context.Background()
+		r, err := p.Query(ctx, "CALL get_users()")
+		if err != nil {
+			t.Fatalf("prefetch %d: query: %v", prefetch, err)
+		}
+
+		var counts []int
+		for {
+			n := 0
+			for r.Next(ctx) {
+				// asked while the rows are being read, as well as once they are exhausted
+				if has := r.HasNextResultSet(); has != (len(counts) == 0) {
+					t.Fatalf("prefetch %d: got %t for a further result set in result set %d", prefetch, has, len(counts))
+				}
+				n++
+			}
+			counts = append(counts, n)
+			if !r.HasNextResultSet() {
+				break
+			}
+			if !r.NextResultSet(ctx) {
+				t.Fatalf("prefetch %d: got no next result set while reported: %v", prefetch, r.Error())
+			}
+		}
+		if err = r.Error(); err != nil {
+			t.Fatalf("prefetch %d: error: %v", prefetch, err)
+		}
+		_ = r.Close(ctx)
+		if len(counts) != 2 || counts[0] != 2 || counts[1] != 1 {
+			t.Fatalf("prefetch %d: got result sets of %v rows, want [2 1]", prefetch, counts)
+		}
+	}
+}
+
+func TestRowsDrainOnCloseCancelledDestroysConnection(t *testing.T) {
+	d := &fakedriver.Driver{
+		Query: func(_ context.Context, _ *fakedriver.Conn, _ string, _ []driver.NamedValue) (driver.Rows, error) {
+			rows := make([][]driver.Value, 1000)
+			for i := range rows {
+				rows[i] = []driver.Value{int64(i)}
+			}
+			r := fakedriver.NewRows([]string{"id"}, rows...)
+			r.NextDelay = time.Millisecond
+			return r, nil
+		},
+	}
+	p := newFakePool(t, d, &Config{ConnectionConfig: &alphasql.ConnectionConfig{DrainOnClose: true}})
+	r, err := p.Query(context.Background(), "SELECT id FROM users")
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	if !r.Next(context.Background()) {
+		t.Fatalf("next: %v", r.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err = r.Close(ctx); !errors.Is(err, alphasql.ErrBadConnection) {
+		t.Fatalf("got %v, want %v", err, alphasql.ErrBadConnection)
+	}
+	eventually(t, func() bool { return d.Closes.Load() == 1 })
+	eventually(t, func() bool { return p.Stat().TotalConnections() == 0 })
+}
@@ -44,8 +44,9 @@ func (p *Pool) QueryRow(ctx context.Context, query string, args ...any) alphasql
 		return p.getPoolErrRow(err)
 	}
 	r := c.QueryRow(ctx, query, args...)
-	if r.Error() != nil {
-		p.closeOrRelease(ctx, c, r.Error())
+	if err = r.Error(); err != nil {
+		p.closeOrRelease(ctx, c, err)
+		return p.getPoolErrRow(err)
 	}
 	return p.getPoolRow(c, r)
 }
@@ -62,9 +63,35 @@ func (p *Pool) Exec(ctx context.Context, query string, args ...any) (alphasql.Re
 	return r, err
 }
 
+// Insert executes an insert query on the acquired Connection and returns the id generated for
+// the inserted row. See [alphasql.Connection.Insert] for details.
+func (p *Pool) Insert(ctx context.Context, query string, args ...any) (int64, error) {
+	c, err := p.Acquire(ctx)
+	if err != nil {
+		return 0, err
+	}
+	id, err := c.Insert(ctx, query, args...)
+	p.closeOrRelease(ctx, c, err)
+	return id, err
+}
+
+// ExecNamed executes a query without returning any rows, reusing the statement prepared under the name
+// provided on the acquired Connection. See [alphasql.Connection.ExecNamed] for details.
+func (p *Pool) ExecNamed(ctx context.Context, name, query string, args ...any) (alphasql.Result, error) {
+	c, err := p.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r, err := c.ExecNamed(ctx, name, query, args...)
+	p.closeOrRelease(ctx, c, err)
+	return r, err
+}
+
 // Prepare creates a prepared statement for later queries or executions.
 // Multiple queries or executions may be run concurrently from the
-// returned statement.
+// returned statement. When [Config.PinPreparedStatements] is set, they
+// are serialised on the pinned Connection, and the rows of a query must
+// be closed before the statement is used again.
 // The caller must call the statement's [alphasql.Statement.Close] method
 // when the statement is no longer needed.
 func (p *Pool) Prepare(ctx context.Context, query string) (alphasql.Statement, error) {
@@ -73,8 +100,22 @@ func (p *Pool) Prepare(ctx context.Context, query string) (alphasql.Statement, e
 		return nil, err
 	}
 	s, err := c.Prepare(ctx, query)
+	if err != nil {
+		p.closeOrRelease(ctx, c, err)
+		return nil, err
+	}
+	ps := p.getPoolStatement(query, s.NumberOfInputs())
+	if p.pinPreparedStatements {
+		ps.c = c
+		ps.s = s
+		return ps, nil
+	}
+	err = s.Close(ctx)
 	p.closeOrRelease(ctx, c, err)
-	return s, err
+	if err != nil {
+		return nil, err
+	}
+	return ps, nil
 }
 
 // BeginTX starts a transaction.
@@ -116,6 +157,10 @@ func (p *Pool) getPoolErrRow(err error) *poolErrRow {
 	return &poolErrRow{err: err}
 }
 
+func (p *Pool) getPoolStatement(query string, inputs int) *poolStatement {
+	return &poolStatement{p: p, query: query, inputs: inputs}
+}
+
 func (p *Pool) getPoolTX(c *Connection, t alphasql.TX) *poolTX {
 	return &poolTX{p: p, c: c, t: t}
 }
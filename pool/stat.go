@@ -0,0 +1,190 @@
+package pool
+
+import "time"
+
+// Stat is a snapshot of the pool statistics.
+type Stat struct {
+	totalConnections        int32
+	idleConnections         int32
+	acquiredConnections     int32
+	constructingConnections int32
+	maxConnections          int32
+	minConnections          int32
+
+	acquireCount         int64
+	acquireDuration      time.Duration
+	emptyAcquireCount    int64
+	idleAcquireCount     int64
+	canceledAcquireCount int64
+	acquireRejectedCount int64
+
+	newConnectionsCount    int64
+	lifetimeDestroyCount   int64
+	resetDestroyCount      int64
+	idleDestroyCount       int64
+	validationDestroyCount int64
+	resetCount             int64
+
+	afterReleaseDestroyCount   int64
+	afterReleaseDestroyReasons map[string]int64
+}
+
+// Stat returns a snapshot of the pool statistics.
+func (p *Pool) Stat() *Stat {
+	p.p.mu.Lock()
+	defer p.p.mu.Unlock()
+	return p.statLocked()
+}
+
+// statLocked returns a snapshot of the pool statistics. The caller must hold the pool lock.
+func (p *Pool) statLocked() *Stat {
+	reasons := make(map[string]int64, len(p.p.afterReleaseDestroyReasons))
+	for reason, count := range p.p.afterReleaseDestroyReasons {
+		reasons[reason] = count
+	}
+	var idle, acquired, constructing int32
+	for _, c := range p.p.allConnections {
+		switch c.status {
+		case connectionStatusIdle:
+			idle++
+		case connectionStatusAcquired:
+			acquired++
+		default:
+			constructing++
+		}
+	}
+	return &Stat{
+		totalConnections:           int32(len(p.p.allConnections)),
+		idleConnections:            idle,
+		acquiredConnections:        acquired,
+		constructingConnections:    constructing,
+		maxConnections:             p.p.maxSize,
+		minConnections:             p.minConnections,
+		acquireCount:               p.p.acquireCount,
+		acquireDuration:            p.p.acquireDuration,
+		emptyAcquireCount:          p.p.emptyAcquireCount,
+		idleAcquireCount:           p.p.idleAcquireCount,
+		canceledAcquireCount:       p.p.canceledAcquireCount.Load(),
+		acquireRejectedCount:       p.acquireRejectedCount.Load(),
+		newConnectionsCount:        p.newConnectionsCount.Load(),
+		lifetimeDestroyCount:       p.lifetimeDestroyCount.Load(),
+		resetDestroyCount:          p.resetDestroyCount.Load(),
+		idleDestroyCount:           p.idleDestroyCount.Load(),
+		validationDestroyCount:     p.validationDestroyCount.Load(),
+		resetCount:                 int64(p.p.resetCount),
+		afterReleaseDestroyCount:   p.afterReleaseDestroyCount.Load(),
+		afterReleaseDestroyReasons: reasons,
+	}
+}
+
+// TotalConnections returns the total number of connections currently in the pool,
+// including the ones being constructed.
+func (s *Stat) TotalConnections() int32 {
+	return s.totalConnections
+}
+
+// IdleConnections returns the number of idle connections currently in the pool.
+func (s *Stat) IdleConnections() int32 {
+	return s.idleConnections
+}
+
+// AcquiredConnections returns the number of connections currently acquired from the pool.
+func (s *Stat) AcquiredConnections() int32 {
+	return s.acquiredConnections
+}
+
+// ConstructingConnections returns the number of connections currently being constructed.
+func (s *Stat) ConstructingConnections() int32 {
+	return s.constructingConnections
+}
+
+// MaxConnections returns the maximum size of the pool.
+func (s *Stat) MaxConnections() int32 {
+	return s.maxConnections
+}
+
+// MinConnections returns the minimum number of connections the pool keeps.
+func (s *Stat) MinConnections() int32 {
+	return s.minConnections
+}
+
+// AcquireCount returns the cumulative count of successful acquires from the pool.
+func (s *Stat) AcquireCount() int64 {
+	return s.acquireCount
+}
+
+// AcquireDuration returns the total duration of all successful acquires from the pool.
+func (s *Stat) AcquireDuration() time.Duration {
+	return s.acquireDuration
+}
+
+// EmptyAcquireCount returns the cumulative count of successful acquires from the pool
+// that waited for a connection to be released or constructed because the pool was empty.
+func (s *Stat) EmptyAcquireCount() int64 {
+	return s.emptyAcquireCount
+}
+
+// IdleAcquireCount returns the cumulative count of successful acquires from the pool
+// that were served immediately by an idle connection without waiting.
+func (s *Stat) IdleAcquireCount() int64 {
+	return s.idleAcquireCount
+}
+
+// CanceledAcquireCount returns the cumulative count of acquires from the pool
+// that were canceled by a context.
+func (s *Stat) CanceledAcquireCount() int64 {
+	return s.canceledAcquireCount
+}
+
+// AcquireRejectedCount returns the cumulative count of acquires from the pool that were rejected because
+// no connection became available within Config.AcquireTimeout. They are counted as canceled as well.
+func (s *Stat) AcquireRejectedCount() int64 {
+	return s.acquireRejectedCount
+}
+
+// NewConnectionsCount returns the cumulative count of new connections opened.
+func (s *Stat) NewConnectionsCount() int64 {
+	return s.newConnectionsCount
+}
+
+// LifetimeDestroyCount returns the cumulative count of connections destroyed
+// because they exceeded Config.MaxConnectionLifetime.
+func (s *Stat) LifetimeDestroyCount() int64 {
+	return s.lifetimeDestroyCount
+}
+
+// ResetDestroyCount returns the cumulative count of connections destroyed
+// because they were recycled by [Pool.Reset] or [Pool.RotateCredentials].
+func (s *Stat) ResetDestroyCount() int64 {
+	return s.resetDestroyCount
+}
+
+// IdleDestroyCount returns the cumulative count of connections destroyed because
+// they exceeded Config.MaxConnectionIdleTime.
+func (s *Stat) IdleDestroyCount() int64 {
+	return s.idleDestroyCount
+}
+
+// ValidationDestroyCount returns the cumulative count of connections destroyed
+// because they failed the validation, including the ones reported invalid by the driver on acquire
+// and the ones failing to reset their session on acquire.
+func (s *Stat) ValidationDestroyCount() int64 {
+	return s.validationDestroyCount
+}
+
+// ResetCount returns the cumulative count of [Pool.Reset] calls.
+func (s *Stat) ResetCount() int64 {
+	return s.resetCount
+}
+
+// AfterReleaseDestroyCount returns the cumulative count of connections destroyed because
+// Config.AfterRelease returned false.
+func (s *Stat) AfterReleaseDestroyCount() int64 {
+	return s.afterReleaseDestroyCount
+}
+
+// AfterReleaseDestroyReasons returns the cumulative count of connections destroyed because
+// Config.AfterRelease returned false, keyed by the reason returned by Config.AfterReleaseDestroyReason.
+func (s *Stat) AfterReleaseDestroyReasons() map[string]int64 {
+	return s.afterReleaseDestroyReasons
+}
@@ -0,0 +1,80 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	alphasql "github.com/sinhashubham95/alpha-sql"
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+)
+
+func TestIdleAcquireCount(t *testing.T) {
+	p := newFakePool(t, &fakedriver.Driver{}, nil)
+	ctx := context.Background()
+
+	c, err := p.Acquire(ctx)
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	if s := p.Stat(); s.IdleAcquireCount() != 0 || s.EmptyAcquireCount() != 1 {
+		t.Fatalf("got idle=%d empty=%d acquires with no idle connection, want idle=0 empty=1",
+			s.IdleAcquireCount(), s.EmptyAcquireCount())
+	}
+	p.Release(ctx, c)
+	eventually(t, func() bool { return p.Stat().IdleConnections() == 1 })
+
+	for i := 1; i <= 3; i++ {
+		c, err = p.Acquire(ctx)
+		if err != nil {
+			t.Fatalf("acquire: %v", err)
+		}
+		if s := p.Stat(); s.IdleAcquireCount() != int64(i) || s.EmptyAcquireCount() != 1 {
+			t.Fatalf("got idle=%d empty=%d, want idle=%d empty=1", s.IdleAcquireCount(), s.EmptyAcquireCount(), i)
+		}
+		p.Release(ctx, c)
+		eventually(t, func() bool { return p.Stat().IdleConnections() == 1 })
+	}
+	if n := p.Stat().AcquireCount(); n != 4 {
+		t.Fatalf("got %d acquires, want 4", n)
+	}
+}
+
+func TestAcquireRejectedCount(t *testing.T) {
+	var rejected atomic.Int64
+	p := newFakePool(t, &fakedriver.Driver{}, &Config{
+		MaxConnections:    1,
+		AcquireTimeout:    20 * time.Millisecond,
+		OnAcquireRejected: func(context.Context) { rejected.Add(1) },
+	})
+	ctx := context.Background()
+
+	c, err := p.Acquire(ctx)
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	defer p.Release(ctx, c)
+	for i := 0; i < 2; i++ {
+		if _, err = p.Acquire(ctx); !errors.Is(err, alphasql.ErrAcquireTimeout) {
+			t.Fatalf("got %v acquiring from the saturated pool, want %v", err, alphasql.ErrAcquireTimeout)
+		}
+	}
+	if n := p.Stat().AcquireRejectedCount(); n != 2 {
+		t.Fatalf("got %d rejected acquires, want 2", n)
+	}
+	if n := rejected.Load(); n != 2 {
+		t.Fatalf("got the hook called %d times, want 2", n)
+	}
+
+	// the acquires given up by the caller are not rejections of the pool
+	cancelCtx, cancel := context.WithTimeout(ctx, 5*time.Millisecond)
+	defer cancel()
+	if _, err = p.Acquire(cancelCtx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want %v", err, context.DeadlineExceeded)
+	}
+	if n := p.Stat().AcquireRejectedCount(); n != 2 || rejected.Load() != 2 {
+		t.Fatalf("got %d rejected acquires and %d hook calls after the cancellation, want 2 of each", n, rejected.Load())
+	}
+}
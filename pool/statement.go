@@ -0,0 +1,122 @@
+package pool
+
+import (
+	"context"
+	alphasql "github.com/sinhashubham95/alpha-sql"
+	"sync"
+	"sync/atomic"
+)
+
+type poolStatement struct {
+	p      *Pool
+	query  string
+	inputs int
+
+	// c and s are only set when the statement is pinned to the Connection it was prepared on.
+	// mu serialises the calls on s, as a Connection must not be used concurrently.
+	c  *Connection
+	s  alphasql.Statement
+	mu sync.Mutex
+
+	closed atomic.Bool
+}
+
+func (p *poolStatement) Close(ctx context.Context) error {
+	if !p.closed.CompareAndSwap(false, true) {
+		return nil
+	}
+	if p.s == nil {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	err := p.s.Close(ctx)
+	p.p.closeOrRelease(ctx, p.c, err)
+	return err
+}
+
+func (p *poolStatement) NumberOfInputs() int {
+	return p.inputs
+}
+
+func (p *poolStatement) Exec(ctx context.Context, args ...any) (alphasql.Result, error) {
+	if p.closed.Load() {
+		return nil, alphasql.ErrStatementClosed
+	}
+	if p.s != nil {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		return p.s.Exec(ctx, args...)
+	}
+	c, s, err := p.prepare(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r, err := s.Exec(ctx, args...)
+	_ = s.Close(ctx)
+	p.p.closeOrRelease(ctx, c, err)
+	return r, err
+}
+
+func (p *poolStatement) Query(ctx context.Context, args ...any) (alphasql.Rows, error) {
+	if p.closed.Load() {
+		return nil, alphasql.ErrStatementClosed
+	}
+	if p.s != nil {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		return p.s.Query(ctx, args...)
+	}
+	c, s, err := p.prepare(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r, err := s.Query(ctx, args...)
+	if err != nil {
+		_ = s.Close(ctx)
+		p.p.closeOrRelease(ctx, c, err)
+		return p.p.getPoolErrRows(err), err
+	}
+	rows := p.p.getPoolRows(c, r)
+	rows.s = s
+	return rows, nil
+}
+
+func (p *poolStatement) QueryRow(ctx context.Context, args ...any) (alphasql.Row, error) {
+	if p.closed.Load() {
+		return nil, alphasql.ErrStatementClosed
+	}
+	if p.s != nil {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		return p.s.QueryRow(ctx, args...)
+	}
+	c, s, err := p.prepare(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r, err := s.QueryRow(ctx, args...)
+	if err != nil {
+		_ = s.Close(ctx)
+		p.p.closeOrRelease(ctx, c, err)
+		return nil, err
+	}
+	row := p.p.getPoolRow(c, r)
+	row.s = s
+	return row, nil
+}
+
+// prepare acquires a Connection and prepares the statement on it.
+// The caller must close the returned statement and release the Connection.
+func (p *poolStatement) prepare(ctx context.Context) (*Connection, alphasql.Statement, error) {
+	c, err := p.p.Acquire(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	s, err := c.Prepare(ctx, p.query)
+	if err != nil {
+		p.p.closeOrRelease(ctx, c, err)
+		return nil, nil, err
+	}
+	return c, s, nil
+}
@@ -0,0 +1,124 @@
+package pool
+
+import (
+	"context"
+	"database/sql/driver"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+)
+
+// execCountingDriver returns a fake driver preparing every execution and counting them.
+func execCountingDriver(execs *atomic.Int64) *fakedriver.Driver {
+	return &fakedriver.Driver{
+		PrepareOnly: true,
+		Exec: func(_ context.Context, _ *fakedriver.Conn, _ string, _ []driver.NamedValue) (driver.Result, error) {
+			execs.Add(1)
+			return driver.RowsAffected(1), nil
+		},
+	}
+}
+
+func TestPreparedStatementExecutedMultipleTimes(t *testing.T) {
+	var execs atomic.Int64
+	d := execCountingDriver(&execs)
+	p := newFakePool(t, d, nil)
+	ctx := context.Background()
+
+	s, err := p.Prepare(ctx, "UPDATE users SET name = $1")
+	if err != nil {
+		t.Fatalf("prepare: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		r, err := s.Exec(ctx, "a")
+		if err != nil {
+			t.Fatalf("exec %d: %v", i, err)
+		}
+		if n, _ := r.RowsAffected(); n != 1 {
+			t.Fatalf("exec %d: got %d rows affected, want 1", i, n)
+		}
+	}
+	if err = s.Close(ctx); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if n := execs.Load(); n != 3 {
+		t.Fatalf("got %d executions, want 3", n)
+	}
+	// the statement is prepared again on the Connection acquired for each execution
+	if n := d.Prepares.Load(); n != 4 {
+		t.Fatalf("got %d prepares, want 4", n)
+	}
+	eventually(t, func() bool { return p.Stat().AcquiredConnections() == 0 })
+}
+
+func TestPinnedPreparedStatementExecutedMultipleTimes(t *testing.T) {
+	var execs atomic.Int64
+	d := execCountingDriver(&execs)
+	p := newFakePool(t, d, &Config{PinPreparedStatements: true})
+	ctx := context.Background()
+
+	s, err := p.Prepare(ctx, "UPDATE users SET name = $1")
+	if err != nil {
+		t.Fatalf("prepare: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err = s.Exec(ctx, "a"); err != nil {
+			t.Fatalf("exec %d: %v", i, err)
+		}
+	}
+	if n := p.Stat().AcquiredConnections(); n != 1 {
+		t.Fatalf("got %d acquired connections, want the pinned one", n)
+	}
+	if err = s.Close(ctx); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if n := execs.Load(); n != 3 {
+		t.Fatalf("got %d executions, want 3", n)
+	}
+	if n := d.Prepares.Load(); n != 1 {
+		t.Fatalf("got %d prepares, want 1", n)
+	}
+	eventually(t, func() bool { return p.Stat().AcquiredConnections() == 0 })
+}
+
+func TestPinnedPreparedStatementExecutedConcurrently(t *testing.T) {
+	// execs is not synchronised, like the state of a driver Connection, so -race reports the concurrent executions
+	var execs int
+	d := &fakedriver.Driver{
+		PrepareOnly: true,
+		Exec: func(_ context.Context, _ *fakedriver.Conn, _ string, _ []driver.NamedValue) (driver.Result, error) {
+			execs++
+			return driver.RowsAffected(1), nil
+		},
+	}
+	p := newFakePool(t, d, &Config{PinPreparedStatements: true})
+	ctx := context.Background()
+
+	s, err := p.Prepare(ctx, "UPDATE users SET name = $1")
+	if err != nil {
+		t.Fatalf("prepare: %v", err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				if _, err := s.Exec(ctx, "a"); err != nil {
+					t.Errorf("exec: %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if err = s.Close(ctx); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if execs != 80 {
+		t.Fatalf("got %d executions, want 80", execs)
+	}
+	eventually(t, func() bool { return p.Stat().AcquiredConnections() == 0 })
+}
@@ -56,3 +56,15 @@ func (p *poolTX) Statement(ctx context.Context, s alphasql.Statement) (alphasql.
 func (p *poolTX) KeepConnectionOnRollback() bool {
 	return p.t.KeepConnectionOnRollback()
 }
+
+func (p *poolTX) Savepoint(ctx context.Context, name string) error {
+	return p.t.Savepoint(ctx, name)
+}
+
+func (p *poolTX) RollbackToSavepoint(ctx context.Context, name string) error {
+	return p.t.RollbackToSavepoint(ctx, name)
+}
+
+func (p *poolTX) ReleaseSavepoint(ctx context.Context, name string) error {
+	return p.t.ReleaseSavepoint(ctx, name)
+}
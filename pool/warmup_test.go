@@ -0,0 +1,97 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	alphasql "github.com/sinhashubham95/alpha-sql"
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+)
+
+var errConnect = errors.New("connect failure")
+
+func TestSynchronousWarmup(t *testing.T) {
+	d := &fakedriver.Driver{}
+	p := newFakePool(t, d, &Config{MinConnections: 3, MaxConnections: 5, SynchronousWarmup: true})
+
+	if s := p.Stat(); s.IdleConnections() != 3 || s.TotalConnections() != 3 {
+		t.Fatalf("got %d idle of %d connections once New returned, want 3 of 3", s.IdleConnections(), s.TotalConnections())
+	}
+	if n := d.Connects.Load(); n != 3 {
+		t.Fatalf("got %d connections established, want 3", n)
+	}
+}
+
+func TestSynchronousWarmupError(t *testing.T) {
+	d := &fakedriver.Driver{Connect: func(context.Context, string) error { return errConnect }}
+	p, err := newFakePoolWithError(t, d, &Config{MinConnections: 2, SynchronousWarmup: true})
+	if !errors.Is(err, errConnect) {
+		t.Fatalf("got %v, want %v", err, errConnect)
+	}
+	if p != nil {
+		t.Fatal("got a pool, want none when the warmup fails")
+	}
+}
+
+func TestRequireMinConnectionsAtStartup(t *testing.T) {
+	var connects atomic.Int64
+	d := &fakedriver.Driver{Connect: func(context.Context, string) error {
+		// the database accepts a single connection
+		if connects.Add(1) > 1 {
+			return errConnect
+		}
+		return nil
+	}}
+	p, err := newFakePoolWithError(t, d, &Config{
+		MinConnections:                 3,
+		SynchronousWarmup:              true,
+		RequireMinConnectionsAtStartup: true,
+	})
+	if !errors.Is(err, alphasql.ErrMinConnectionsNotMet) || !errors.Is(err, errConnect) {
+		t.Fatalf("got %v, want %v wrapping %v", err, alphasql.ErrMinConnectionsNotMet, errConnect)
+	}
+	if p != nil {
+		t.Fatal("got a pool, want none when the minimum connections are not met")
+	}
+	eventually(t, func() bool { return d.Closes.Load() == d.Connects.Load() })
+}
+
+func TestRequireMinConnectionsAtStartupMet(t *testing.T) {
+	d := &fakedriver.Driver{}
+	p := newFakePool(t, d, &Config{MinConnections: 2, SynchronousWarmup: true, RequireMinConnectionsAtStartup: true})
+	if n := p.Stat().TotalConnections(); n != 2 {
+		t.Fatalf("got %d connections once New returned, want 2", n)
+	}
+}
+
+func TestRequireMinConnectionsAtStartupInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *Config
+		err  error
+	}{
+		{
+			name: "without a synchronous warmup",
+			cfg:  &Config{MinConnections: 2, RequireMinConnectionsAtStartup: true},
+			err:  alphasql.ErrMinConnectionsWithoutWarmup,
+		},
+		{
+			name: "above the maximum",
+			cfg:  &Config{MinConnections: 3, MaxConnections: 2, SynchronousWarmup: true, RequireMinConnectionsAtStartup: true},
+			err:  alphasql.ErrInvalidMinConnections,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &fakedriver.Driver{}
+			if _, err := newFakePoolWithError(t, d, tt.cfg); !errors.Is(err, tt.err) {
+				t.Fatalf("got %v, want %v", err, tt.err)
+			}
+			if n := d.Connects.Load(); n != 0 {
+				t.Fatalf("got %d connections established, want none", n)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package alphasql
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+type prefetchedRow struct {
+	values []driver.Value
+	err    error
+	size   int64
+}
+
+// prefetcher reads the rows of the current result set ahead in the background.
+//
+// Once started, the prefetcher is the only one calling Next on the driver rows, until
+// either the result set is exhausted or it is stopped.
+type prefetcher struct {
+	rows chan prefetchedRow
+	quit chan struct{}
+	done chan struct{}
+
+	maxBufferedBytes int64
+	bufferedBytes    atomic.Int64
+
+	// unsent is the row read from the driver but not sent yet when the prefetching was stopped.
+	unsent *prefetchedRow
+}
+
+func startPrefetch(r driver.Rows, size, columns int, maxBufferedBytes int64) *prefetcher {
+	p := &prefetcher{
+		rows:             make(chan prefetchedRow, size),
+		quit:             make(chan struct{}),
+		done:             make(chan struct{}),
+		maxBufferedBytes: maxBufferedBytes,
+	}
+	go p.run(r, columns)
+	return p
+}
+
+func (p *prefetcher) run(r driver.Rows, columns int) {
+	defer close(p.done)
+	defer close(p.rows)
+	for {
+		vs := make([]driver.Value, columns)
+		err := r.Next(vs)
+		var size int64
+		if err == nil {
+			// the driver owns the memory of the byte slices only until the next call to Next
+			for i, v := range vs {
+				if b, ok := v.([]byte); ok {
+					vs[i] = bytes.Clone(b)
+				}
+			}
+			size = approximateRowSize(vs)
+			if p.maxBufferedBytes > 0 && p.bufferedBytes.Add(size) > p.maxBufferedBytes {
+				vs, err = nil, ErrResultTooLarge
+			}
+		}
+		pr := prefetchedRow{values: vs, err: err, size: size}
+		select {
+		case p.rows <- pr:
+		case <-p.quit:
+			p.unsent = &pr
+			return
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (p *prefetcher) next(dest []driver.Value) error {
+	pr, ok := <-p.rows
+	if !ok {
+		return io.EOF
+	}
+	p.bufferedBytes.Add(-pr.size)
+	copy(dest, pr.values)
+	return pr.err
+}
+
+// stop stops the prefetching, waiting for any in-flight call to the driver to return.
+func (p *prefetcher) stop() {
+	close(p.quit)
+	<-p.done
+}
+
+// pause stops the prefetching like stop, returning the rows read from the driver but not consumed yet, in the
+// order they were read, so they are not lost when the driver rows have to be called by someone else.
+func (p *prefetcher) pause() []prefetchedRow {
+	p.stop()
+	var rows []prefetchedRow
+	for pr := range p.rows {
+		rows = append(rows, pr)
+	}
+	if p.unsent != nil {
+		rows = append(rows, *p.unsent)
+	}
+	return rows
+}
+
+// approximateRowSize returns the approximate number of bytes held by the values of a row.
+func approximateRowSize(vs []driver.Value) int64 {
+	var size int64
+	for _, v := range vs {
+		switch t := v.(type) {
+		case []byte:
+			size += int64(len(t))
+		case string:
+			size += int64(len(t))
+		case time.Time:
+			size += 24
+		default:
+			size += 8
+		}
+	}
+	return size
+}
@@ -0,0 +1,208 @@
+package alphasql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+)
+
+const prefetchTestRows = 20
+
+func slowRows() *fakedriver.Rows {
+	rows := make([][]driver.Value, prefetchTestRows)
+	for i := range rows {
+		rows[i] = []driver.Value{int64(i), []byte{byte(i)}}
+	}
+	r := fakedriver.NewRows([]string{"n", "b"}, rows...)
+	r.NextDelay = 5 * time.Millisecond
+	return r
+}
+
+// consumeSlowly reads all the rows, spending as long on each row as the driver does, and checks their values.
+func consumeSlowly(t *testing.T, cfg *ConnectionConfig) time.Duration {
+	t.Helper()
+	ctx := context.Background()
+	c := connectFake(t, rowsDriver(slowRows), cfg)
+	start := time.Now()
+	r, err := c.Query(ctx, "SELECT n, b")
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	var i int64
+	for ; r.Next(ctx); i++ {
+		var n int64
+		var b []byte
+		if err = r.Scan(&n, &b); err != nil {
+			t.Fatalf("scan: %v", err)
+		}
+		if n != i || len(b) != 1 || b[0] != byte(i) {
+			t.Fatalf("got row (%d, %v), want (%d, [%d])", n, b, i, i)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if err = r.Error(); err != nil {
+		t.Fatalf("rows: %v", err)
+	}
+	if i != prefetchTestRows {
+		t.Fatalf("got %d rows, want %d", i, prefetchTestRows)
+	}
+	if err = r.Close(ctx); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	return time.Since(start)
+}
+
+func TestRowPrefetch(t *testing.T) {
+	sequential := consumeSlowly(t, nil)
+	prefetched := consumeSlowly(t, &ConnectionConfig{RowPrefetch: 4})
+	if prefetched >= sequential*4/5 {
+		t.Fatalf("got %s with prefetch and %s without, want prefetch to overlap the reads", prefetched, sequential)
+	}
+}
+
+func TestRowPrefetchCloseStopsPrefetcher(t *testing.T) {
+	ctx := context.Background()
+	var fr *fakedriver.Rows
+	c := connectFake(t, rowsDriver(func() *fakedriver.Rows {
+		fr = slowRows()
+		return fr
+	}), &ConnectionConfig{RowPrefetch: 4})
+	r, err := c.Query(ctx, "SELECT n, b")
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	if !r.Next(ctx) {
+		t.Fatalf("next: %v", r.Error())
+	}
+	if err = r.Close(ctx); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if !fr.Closed() {
+		t.Fatal("got the driver rows open after close")
+	}
+	if fr.Drained() {
+		t.Fatal("got the driver rows drained, want the prefetcher stopped early")
+	}
+	if r.Next(ctx) {
+		t.Fatal("got a row after close")
+	}
+}
+
+func TestRowPrefetchMaxBufferedBytes(t *testing.T) {
+	ctx := context.Background()
+	c := connectFake(t, largeRowsDriver(100), &ConnectionConfig{RowPrefetch: 50, MaxBufferedBytes: 1000})
+	r, err := c.Query(ctx, "SELECT b FROM blobs")
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	defer func() { _ = r.Close(ctx) }()
+
+	if !r.Next(ctx) {
+		t.Fatalf("next: %v", r.Error())
+	}
+	// not consuming the rows lets the prefetcher buffer them until it exceeds the limit
+	time.Sleep(50 * time.Millisecond)
+	n := 1
+	for r.Next(ctx) {
+		n++
+	}
+	if !errors.Is(r.Error(), ErrResultTooLarge) {
+		t.Fatalf("got %v, want %v", r.Error(), ErrResultTooLarge)
+	}
+	if n >= 100 {
+		t.Fatalf("got all the %d rows, want the prefetching aborted", n)
+	}
+}
+
+func TestRowPrefetchUnderMaxBufferedBytes(t *testing.T) {
+	ctx := context.Background()
+	c := connectFake(t, largeRowsDriver(100), &ConnectionConfig{RowPrefetch: 5, MaxBufferedBytes: 1000})
+	r, err := c.Query(ctx, "SELECT b FROM blobs")
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	defer func() { _ = r.Close(ctx) }()
+
+	// the buffer of 5 rows never holds more than the limit, however slowly the rows are consumed
+	n := 0
+	for r.Next(ctx) {
+		if n == 0 {
+			time.Sleep(20 * time.Millisecond)
+		}
+		n++
+	}
+	if err := r.Error(); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if n != 100 {
+		t.Fatalf("got %d rows, want 100", n)
+	}
+}
+
+// unsyncRows counts the calls made to the fake rows without any synchronisation, like the driver rows not being
+// safe for concurrent use, so the race detector reports the calls made concurrently.
+type unsyncRows struct {
+	*fakedriver.Rows
+	calls int
+}
+
+func (r *unsyncRows) Next(dest []driver.Value) error {
+	r.calls++
+	return r.Rows.Next(dest)
+}
+
+func (r *unsyncRows) HasNextResultSet() bool {
+	r.calls++
+	return r.Rows.HasNextResultSet()
+}
+
+func TestRowPrefetchHasNextResultSet(t *testing.T) {
+	d := &fakedriver.Driver{
+		Query: func(context.Context, *fakedriver.Conn, string, []driver.NamedValue) (driver.Rows, error) {
+			r := slowRows()
+			r.NextDelay = time.Millisecond
+			r.Sets = append(r.Sets, fakedriver.ResultSet{Columns: []string{"n"}, Rows: [][]driver.Value{{int64(-1)}}})
+			return &unsyncRows{Rows: r}, nil
+		},
+	}
+	ctx := context.Background()
+	c := connectFake(t, d, &ConnectionConfig{RowPrefetch: 4})
+	r, err := c.Query(ctx, "SELECT n, b")
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	defer func() { _ = r.Close(ctx) }()
+
+	var i int64
+	for ; r.Next(ctx); i++ {
+		if !r.HasNextResultSet() {
+			t.Fatalf("got no further result set at row %d, want one", i)
+		}
+		var n int64
+		var b []byte
+		if err = r.Scan(&n, &b); err != nil {
+			t.Fatalf("scan: %v", err)
+		}
+		// the rows read ahead before the prefetching was paused are returned in order
+		if n != i || len(b) != 1 || b[0] != byte(i) {
+			t.Fatalf("got row (%d, %v), want (%d, [%d])", n, b, i, i)
+		}
+	}
+	if err = r.Error(); err != nil {
+		t.Fatalf("rows: %v", err)
+	}
+	if i != prefetchTestRows {
+		t.Fatalf("got %d rows, want %d", i, prefetchTestRows)
+	}
+	if !r.NextResultSet(ctx) || !r.Next(ctx) {
+		t.Fatalf("got no row in the further result set: %v", r.Error())
+	}
+	var n int64
+	if err = r.Scan(&n); err != nil || n != -1 {
+		t.Fatalf("got %d, %v, want -1", n, err)
+	}
+}
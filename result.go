@@ -8,14 +8,22 @@ type Result interface {
 	RowsAffected() (int64, error)
 }
 
+// result wraps the driver result of an execution. Some drivers return no result for certain statements, such as DDL,
+// in which case its methods return [ErrResultUnavailable].
 type result struct {
 	r driver.Result
 }
 
 func (r *result) LastInsertID() (int64, error) {
+	if r.r == nil {
+		return 0, ErrResultUnavailable
+	}
 	return r.r.LastInsertId()
 }
 
 func (r *result) RowsAffected() (int64, error) {
+	if r.r == nil {
+		return 0, ErrResultUnavailable
+	}
 	return r.r.RowsAffected()
 }
@@ -0,0 +1,78 @@
+package alphasql
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// RetryPolicy describes how an operation is retried on failure.
+//
+// The delay before the n-th retry is BaseDelay * 2^(n-1), capped at MaxDelay when it is set,
+// with a random duration in [0, Jitter) added to it.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times the operation is attempted, including the first attempt.
+	// A value less than 1 is treated as a single attempt.
+	MaxAttempts int
+
+	// BaseDelay is the delay before the first retry.
+	BaseDelay time.Duration
+
+	// MaxDelay is the upper bound of the delay between the attempts. Zero means no upper bound.
+	MaxDelay time.Duration
+
+	// Jitter is the upper bound of the random duration added to each delay, to prevent retries
+	// from different callers happening at the exact same time.
+	Jitter time.Duration
+
+	// Retryable reports whether the operation should be retried for the error returned by it.
+	// If it is nil, every error is retried.
+	Retryable func(error) bool
+}
+
+// Do calls fn until it succeeds, returns an error that is not retryable, or the attempts are exhausted.
+// It returns the error from the last attempt, or the context error if the context is done while waiting
+// between the attempts.
+func (r *RetryPolicy) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	attempts := r.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = fn(ctx)
+		if err == nil || attempt >= attempts || !r.isRetryable(err) {
+			return err
+		}
+		t := time.NewTimer(r.Delay(attempt))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// Delay returns the delay to wait for after the given attempt, before the next attempt is made.
+func (r *RetryPolicy) Delay(attempt int) time.Duration {
+	d := r.BaseDelay
+	for i := 1; i < attempt && d < math.MaxInt64/2 && (r.MaxDelay <= 0 || d < r.MaxDelay); i++ {
+		d *= 2
+	}
+	if r.MaxDelay > 0 && d > r.MaxDelay {
+		d = r.MaxDelay
+	}
+	if r.Jitter > 0 {
+		d += time.Duration(rand.Int64N(int64(r.Jitter)))
+	}
+	return d
+}
+
+func (r *RetryPolicy) isRetryable(err error) bool {
+	if r.Retryable == nil {
+		return true
+	}
+	return r.Retryable(err)
+}
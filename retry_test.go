@@ -0,0 +1,103 @@
+package alphasql
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyDelay(t *testing.T) {
+	r := RetryPolicy{BaseDelay: 10 * time.Millisecond, MaxDelay: 50 * time.Millisecond}
+	for _, tc := range []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: 10 * time.Millisecond},
+		{attempt: 2, want: 20 * time.Millisecond},
+		{attempt: 3, want: 40 * time.Millisecond},
+		{attempt: 4, want: 50 * time.Millisecond},
+		{attempt: 100, want: 50 * time.Millisecond},
+	} {
+		if got := r.Delay(tc.attempt); got != tc.want {
+			t.Errorf("attempt %d: got %s, want %s", tc.attempt, got, tc.want)
+		}
+	}
+}
+
+func TestRetryPolicyDelayJitter(t *testing.T) {
+	r := RetryPolicy{BaseDelay: 10 * time.Millisecond, Jitter: 5 * time.Millisecond}
+	for i := 0; i < 100; i++ {
+		if d := r.Delay(1); d < 10*time.Millisecond || d >= 15*time.Millisecond {
+			t.Fatalf("got %s, want a delay in [10ms, 15ms)", d)
+		}
+	}
+}
+
+func TestRetryPolicyAttemptCap(t *testing.T) {
+	errFailed := errors.New("failed")
+	r := RetryPolicy{MaxAttempts: 3}
+	var attempts int
+	err := r.Do(context.Background(), func(_ context.Context) error {
+		attempts++
+		return errFailed
+	})
+	if !errors.Is(err, errFailed) {
+		t.Fatalf("got %v, want %v", err, errFailed)
+	}
+	if attempts != 3 {
+		t.Fatalf("got %d attempts, want 3", attempts)
+	}
+}
+
+func TestRetryPolicyStopsOnSuccessAndNonRetryable(t *testing.T) {
+	errPermanent := errors.New("permanent")
+	r := RetryPolicy{MaxAttempts: 5, Retryable: func(err error) bool { return !errors.Is(err, errPermanent) }}
+
+	var attempts int
+	err := r.Do(context.Background(), func(_ context.Context) error {
+		attempts++
+		if attempts < 2 {
+			return errors.New("transient")
+		}
+		return nil
+	})
+	if err != nil || attempts != 2 {
+		t.Fatalf("got %v after %d attempts, want success after 2", err, attempts)
+	}
+
+	attempts = 0
+	err = r.Do(context.Background(), func(_ context.Context) error {
+		attempts++
+		return errPermanent
+	})
+	if !errors.Is(err, errPermanent) || attempts != 1 {
+		t.Fatalf("got %v after %d attempts, want %v after 1", err, attempts, errPermanent)
+	}
+}
+
+func TestRetryPolicyContextCancellation(t *testing.T) {
+	r := RetryPolicy{MaxAttempts: 10, BaseDelay: time.Hour}
+	ctx, cancel := context.WithCancel(context.Background())
+	var attempts int
+	done := make(chan error)
+	go func() {
+		done <- r.Do(ctx, func(_ context.Context) error {
+			attempts++
+			return errors.New("failed")
+		})
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("got %v, want %v", err, context.Canceled)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Do did not return once the context was canceled")
+	}
+	if attempts != 1 {
+		t.Fatalf("got %d attempts, want 1", attempts)
+	}
+}
@@ -3,6 +3,7 @@ package alphasql
 import (
 	"context"
 	"database/sql/driver"
+	"errors"
 )
 
 type Row interface {
@@ -13,6 +14,9 @@ type Row interface {
 	// the query, Scan returns [ErrNoRows].
 	Scan(ctx context.Context, values ...any) error
 
+	// Columns returns the columns of the matched row. They are available before calling [Row.Scan],
+	// so they can be used to build the scan destinations, and remain available after [Row.Scan] closes
+	// the underlying rows, the columns read while moving to the row being kept. If the query failed, nil is returned.
 	Columns() []Column
 
 	// Error provides a way for wrapping packages to check for
@@ -47,6 +51,9 @@ func (r *row) Scan(ctx context.Context, values ...any) error {
 }
 
 func (r *row) Columns() []Column {
+	if r.err != nil {
+		return nil
+	}
 	return r.r.Columns()
 }
 
@@ -56,9 +63,8 @@ func (r *row) Error() error {
 
 func (r *row) close(ctx context.Context) error {
 	err := r.r.Close(ctx)
-	if err != nil {
-		_ = r.s.Close()
+	if r.s == nil {
 		return err
 	}
-	return r.s.Close()
+	return errors.Join(err, r.s.Close())
 }
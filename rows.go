@@ -3,7 +3,10 @@ package alphasql
 import (
 	"context"
 	"database/sql/driver"
+	"errors"
+	"fmt"
 	"io"
+	"time"
 )
 
 // Rows is the result of a query. Its cursor starts before the first row
@@ -27,6 +30,11 @@ type Rows interface {
 	// set.
 	NextResultSet(ctx context.Context) bool
 
+	// HasNextResultSet reports whether there is a further result set after the current one,
+	// without advancing to it. It returns false once the [Rows] are closed, or if the driver
+	// does not support multiple result sets.
+	HasNextResultSet() bool
+
 	// Error returns the error, if any, that was encountered during iteration.
 	// Error may be called after an explicit or implicit [Rows.Close].
 	Error() error
@@ -35,6 +43,9 @@ type Rows interface {
 	// and returns false and there are no further result sets,
 	// the [Rows] are closed automatically, and it will suffice to check the
 	// result of [Rows.Err]. Close is idempotent and does not affect the result of [Rows.Error].
+	// If the [Rows] own the statement they were queried with, it is closed as well, and its close
+	// error is joined with the one of the [Rows]. With [ConnectionConfig.DrainOnClose], the remaining
+	// rows are read until ctx is done, after which [ErrBadConnection] is returned.
 	Close(ctx context.Context) error
 
 	// Scan copies the columns in the current row into the values pointed
@@ -52,8 +63,10 @@ type Rows interface {
 	//	*float32, *float64
 	//	*interface{}
 	//	*RawBytes
+	//	*io.Reader
 	//	*Rows (cursor value)
 	//	any type implementing Scanner (see Scanner docs)
+	//	any type implementing encoding.TextUnmarshaler, for the string and []byte columns
 	//
 	// In the most simple case, if the type of the value from the source
 	// column is an integer, bool or string type T and dest is of type *T,
@@ -69,6 +82,10 @@ type Rows interface {
 	// strings may lose information when stringing. In general, scan
 	// floating point columns into *float64.
 	//
+	// A source value of type []byte or string may also be scanned into a
+	// pointer to a fixed-size byte array such as *[16]byte, provided its
+	// length matches the length of the array exactly.
+	//
 	// If a dest argument has type *[]byte, Scan saves in that argument a
 	// copy of the corresponding data. The copy is owned by the caller and
 	// can be modified and held indefinitely. The copy can be avoided by
@@ -84,6 +101,14 @@ type Rows interface {
 	// *time.Time, *interface{}, *string, or *[]byte. When converting to
 	// the latter two, [time.RFC3339Nano] is used.
 	//
+	// Columns without a time zone such as DATE or TIME may be scanned into
+	// *Date or *TimeOfDay, from a source of type [time.Time], string or []byte.
+	//
+	// A column may be scanned into an *io.Reader to read its bytes on demand, for instance a large BLOB.
+	// If the driver provides the value as an [io.Reader], it is streamed from the driver, and it can only be
+	// read until the next call to [Rows.Next] or [Rows.Close]. Values of type []byte or string are copied.
+	// NULL columns set the reader to nil.
+	//
 	// Source values of type bool may be scanned into types *bool,
 	// *interface{}, *string, *[]byte, or [*RawBytes].
 	//
@@ -95,15 +120,47 @@ type Rows interface {
 	// [Rows] value that can itself be scanned from. The parent
 	// select query will close any cursor [Rows] if the parent [Rows] is closed.
 	//
-	// If any of the first arguments implementing [driver.Scanner] returns an error,
-	// that error will be wrapped in the returned error.
+	// Scanning a NULL column into a *time.Time fails, unless [ConnectionConfig.NullTimeAsZero]
+	// is set, in which case it is set to the zero time.
+	//
+	// If a [ColumnDecoder] is configured in [ConnectionConfig.ColumnDecoders] for the
+	// database type name of a column, the value is decoded with it before the conversion.
+	//
+	// If a column cannot be converted into its destination, the error returned wraps both
+	// [ErrRowsUnexpectedScan] and the conversion error, such as the error returned by the
+	// Scan method of a destination implementing [Scanner], along with the index of the column.
 	Scan(values ...any) error
 
 	// Columns are used to provide the current set of columns in the result set.
-	// Similar to how until [Rows.Next] is not called, [Rows.Scan] won't work, [Rows.Columns]
-	// will also return stale or nil data until [Rows.Next] is called.
-	// Even though the result set has changed, until [Rows.Next] is called, the column list won't be updated.
+	// They are read from the driver on the first call to either [Rows.Columns] or [Rows.Next],
+	// so they are available before the first row is read. After moving to the next result set,
+	// the columns of the new result set are read the same way. Once the [Rows] are closed, the columns
+	// read until then are returned, or nil if none were read.
 	Columns() []Column
+
+	// DecodeInto decodes the current row into dest using the [RowCodec] configured in [ConnectionConfig.RowCodec].
+	// Like [Rows.Scan], every call to DecodeInto must be preceded by a call to [Rows.Next].
+	// If no codec is configured, [ErrRowCodecNotConfigured] is returned.
+	DecodeInto(dest any) error
+
+	// ScanAll scans the values of all the remaining rows of the current result set, returning them along with
+	// the names of the columns, and closes the [Rows]. It is meant for small results, as all the rows are held
+	// in memory. The context is checked between the rows, returning its error once done. If the rows held
+	// exceed [ConnectionConfig.MaxBufferedBytes], [ErrResultTooLarge] is returned.
+	ScanAll(ctx context.Context) ([][]any, []string, error)
+
+	// Values returns the values of the current row, converted the same way as scanning each column into
+	// an *interface{} with [Rows.Scan] does, so for instance a [time.Time] column comes back as [time.Time].
+	// It returns [ErrRowsClosed] or [ErrRowsScanWithoutNext] in the same situations as [Rows.Scan].
+	Values() ([]any, error)
+
+	// RawValues returns the values of the current row as bytes, without copying the values of the
+	// []byte columns. The values of the other columns are formatted the same way as scanning them into
+	// a *[]byte with [Rows.Scan] does, and NULL columns are nil. It returns nil in the situations where
+	// [Rows.Scan] would fail.
+	//
+	// The returned slices are only valid until the next call to [Rows.Next], [Rows.Scan], or [Rows.Close].
+	RawValues() [][]byte
 }
 
 type rows struct {
@@ -115,6 +172,19 @@ type rows struct {
 
 	current []driver.Value
 	columns []Column
+
+	// buffer holds the values of the rows, allocated when the rows are created and reused across
+	// the result sets having the same number of columns, while current is only set once Next is called.
+	buffer []driver.Value
+
+	cfg      *ConnectionConfig
+	prefetch *prefetcher
+	// pending holds the rows read by a paused prefetcher, returned by Next before any further row is read.
+	pending []prefetchedRow
+}
+
+func newRows(s driver.Stmt, r driver.Rows, cfg *ConnectionConfig) *rows {
+	return &rows{s: s, r: r, cfg: cfg, buffer: make([]driver.Value, len(r.Columns()))}
 }
 
 func (r *rows) Next(ctx context.Context) bool {
@@ -137,6 +207,7 @@ func (r *rows) NextResultSet(ctx context.Context) bool {
 	}
 	r.current = nil
 	r.columns = nil
+	r.stopPrefetch()
 	nextResultSet, ok := r.r.(driver.RowsNextResultSet)
 	if !ok {
 		_ = r.Close(ctx)
@@ -150,6 +221,16 @@ func (r *rows) NextResultSet(ctx context.Context) bool {
 	return true
 }
 
+func (r *rows) HasNextResultSet() bool {
+	if r.closed {
+		return false
+	}
+	// the prefetcher may be calling Next on the driver rows, which are not safe for concurrent use
+	r.pausePrefetch()
+	nextResultSet, ok := r.r.(driver.RowsNextResultSet)
+	return ok && nextResultSet.HasNextResultSet()
+}
+
 func (r *rows) Error() error {
 	if r.err != nil && r.err != io.EOF {
 		return r.err
@@ -157,11 +238,91 @@ func (r *rows) Error() error {
 	return nil
 }
 
-func (r *rows) Close(_ context.Context) error {
-	return r.close(nil)
+func (r *rows) Close(ctx context.Context) error {
+	return r.close(ctx, nil)
 }
 
 func (r *rows) Scan(vs ...any) error {
+	if err := r.checkCurrent(); err != nil {
+		return err
+	}
+	if len(vs) != len(r.current) {
+		return ErrRowsUnexpectedScanValues
+	}
+	for i := range r.current {
+		v, err := r.value(i)
+		if err != nil {
+			return err
+		}
+		err = r.convertAssign(v, vs[i])
+		if err != nil {
+			return fmt.Errorf("%w: column %d: %w", ErrRowsUnexpectedScan, i, err)
+		}
+	}
+	return nil
+}
+
+func (r *rows) convertAssign(src, dest any) error {
+	if t, ok := dest.(*time.Time); ok && t != nil && src == nil && r.cfg.NullTimeAsZero {
+		*t = time.Time{}
+		return nil
+	}
+	return convertAssignRows(src, dest)
+}
+
+// value returns the value of the i-th column of the current row, decoded using the
+// decoder configured for its database type name if any.
+func (r *rows) value(i int) (any, error) {
+	v := r.current[i]
+	if v == nil || len(r.cfg.ColumnDecoders) == 0 {
+		return v, nil
+	}
+	d, ok := r.cfg.ColumnDecoders[r.columns[i].DatabaseTypeName()]
+	if !ok {
+		return v, nil
+	}
+	return d(v)
+}
+
+func (r *rows) Values() ([]any, error) {
+	if err := r.checkCurrent(); err != nil {
+		return nil, err
+	}
+	vs := make([]any, len(r.current))
+	for i := range r.current {
+		v, err := r.value(i)
+		if err != nil {
+			return nil, err
+		}
+		err = convertAssignRows(v, &vs[i])
+		if err != nil {
+			return nil, fmt.Errorf("%w: column %d: %w", ErrRowsUnexpectedScan, i, err)
+		}
+	}
+	return vs, nil
+}
+
+func (r *rows) RawValues() [][]byte {
+	if r.checkCurrent() != nil {
+		return nil
+	}
+	vs := make([][]byte, len(r.current))
+	for i, v := range r.current {
+		switch b := v.(type) {
+		case nil:
+		case []byte:
+			vs[i] = b
+		default:
+			var rb RawBytes
+			if convertAssignRows(v, &rb) == nil {
+				vs[i] = rb
+			}
+		}
+	}
+	return vs
+}
+
+func (r *rows) checkCurrent() error {
 	if r.err != nil && r.err != io.EOF {
 		return r.err
 	}
@@ -171,23 +332,59 @@ func (r *rows) Scan(vs ...any) error {
 	if r.current == nil {
 		return ErrRowsScanWithoutNext
 	}
-	if len(vs) != len(r.current) {
-		return ErrRowsUnexpectedScanValues
-	}
-	for i, v := range r.current {
-		err := convertAssignRows(v, vs[i])
-		if err != nil {
-			return ErrRowsUnexpectedScan
-		}
-	}
 	return nil
 }
 
 func (r *rows) Columns() []Column {
+	if r.columns == nil && !r.closed {
+		r.columns = getColumnsFromDriverColumns(r.r, r.cfg.ColumnScanTypeByName)
+	}
 	return r.columns
 }
 
-func (r *rows) close(err error) error {
+func (r *rows) DecodeInto(dest any) error {
+	if err := r.checkCurrent(); err != nil {
+		return err
+	}
+	if r.cfg.RowCodec == nil {
+		return ErrRowCodecNotConfigured
+	}
+	values := make([]any, len(r.current))
+	for i, v := range r.current {
+		values[i] = v
+	}
+	return r.cfg.RowCodec.DecodeRow(r.columns, values, dest)
+}
+
+func (r *rows) ScanAll(ctx context.Context) ([][]any, []string, error) {
+	defer func() { _ = r.Close(ctx) }()
+	var values [][]any
+	var size int64
+	for r.Next(ctx) {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		size += approximateRowSize(r.current)
+		if r.cfg.MaxBufferedBytes > 0 && size > r.cfg.MaxBufferedBytes {
+			return nil, nil, ErrResultTooLarge
+		}
+		vs, err := scanRowValues(r)
+		if err != nil {
+			return nil, nil, err
+		}
+		values = append(values, vs)
+	}
+	if err := r.Error(); err != nil {
+		return nil, nil, err
+	}
+	names := make([]string, len(r.columns))
+	for i := range r.columns {
+		names[i] = r.columns[i].Name()
+	}
+	return values, names, nil
+}
+
+func (r *rows) close(ctx context.Context, err error) error {
 	if r.closed {
 		return nil
 	}
@@ -195,26 +392,74 @@ func (r *rows) close(err error) error {
 	if r.err == nil {
 		r.err = err
 	}
-	err = r.r.Close()
+	r.stopPrefetch()
+	if err == nil && r.cfg.DrainOnClose {
+		err = r.drain(ctx)
+	}
+	err = errors.Join(err, r.r.Close())
 	if r.s != nil {
-		_ = r.s.Close()
+		err = errors.Join(err, r.s.Close())
 	}
 	return err
 }
 
+// drain reads the remaining rows of the current and the further result sets from the driver, so the connection is
+// left clean for the drivers requiring the results to be fully consumed before it is reused. If the context is done
+// before all the rows are read, it returns [ErrBadConnection], as the connection is left with rows pending.
+func (r *rows) drain(ctx context.Context) error {
+	nextResultSet, _ := r.r.(driver.RowsNextResultSet)
+	for {
+		vs := make([]driver.Value, len(r.r.Columns()))
+		var err error
+		for err == nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return fmt.Errorf("%w: draining the rows: %w", ErrBadConnection, ctxErr)
+			}
+			err = r.r.Next(vs)
+		}
+		if err != io.EOF {
+			return err
+		}
+		if nextResultSet == nil || !nextResultSet.HasNextResultSet() {
+			return nil
+		}
+		if err = nextResultSet.NextResultSet(); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
 func (r *rows) next() (doClose bool, ok bool) {
 	if r.closed {
 		return false, false
 	}
 
 	if r.columns == nil {
-		r.columns = getColumnsFromDriverColumns(r.r)
+		r.columns = getColumnsFromDriverColumns(r.r, r.cfg.ColumnScanTypeByName)
 	}
 	if r.current == nil {
-		r.current = make([]driver.Value, len(r.columns))
+		if len(r.buffer) != len(r.columns) {
+			r.buffer = make([]driver.Value, len(r.columns))
+		}
+		r.current = r.buffer
+	}
+	if len(r.pending) > 0 {
+		copy(r.current, r.pending[0].values)
+		r.err = r.pending[0].err
+		r.pending = r.pending[1:]
+	} else {
+		if r.cfg.RowPrefetch > 0 && r.prefetch == nil {
+			r.prefetch = startPrefetch(r.r, r.cfg.RowPrefetch, len(r.columns), r.cfg.MaxBufferedBytes)
+		}
+		if r.prefetch != nil {
+			r.err = r.prefetch.next(r.current)
+		} else {
+			r.err = r.r.Next(r.current)
+		}
 	}
-
-	r.err = r.r.Next(r.current)
 	if r.err != nil {
 		// Close the connection if there is a driver error.
 		if r.err != io.EOF {
@@ -231,3 +476,20 @@ func (r *rows) next() (doClose bool, ok bool) {
 	}
 	return false, true
 }
+
+func (r *rows) stopPrefetch() {
+	if r.prefetch != nil {
+		r.prefetch.stop()
+		r.prefetch = nil
+	}
+	r.pending = nil
+}
+
+// pausePrefetch stops the prefetching, keeping the rows read so far to be returned by Next, the prefetching resuming
+// once they are consumed.
+func (r *rows) pausePrefetch() {
+	if r.prefetch != nil {
+		r.pending = append(r.pending, r.prefetch.pause()...)
+		r.prefetch = nil
+	}
+}
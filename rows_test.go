@@ -0,0 +1,416 @@
+package alphasql
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+)
+
+// queryOne queries a single row having a single column holding the value provided.
+func queryOne(t *testing.T, cfg *ConnectionConfig, v driver.Value) Rows {
+	t.Helper()
+	c := connectFake(t, rowsDriver(func() *fakedriver.Rows {
+		return fakedriver.NewRows([]string{"v"}, []driver.Value{v})
+	}), cfg)
+	r, err := c.Query(context.Background(), "SELECT v")
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	t.Cleanup(func() { _ = r.Close(context.Background()) })
+	if !r.Next(context.Background()) {
+		t.Fatalf("next: %v", r.Error())
+	}
+	return r
+}
+
+func TestScanByteArray(t *testing.T) {
+	want := [16]byte{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}
+	var got [16]byte
+	if err := queryOne(t, nil, want[:]).Scan(&got); err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	if got != want {
+		t.Fatalf("got %x, want %x", got, want)
+	}
+}
+
+func TestScanByteArrayLengthMismatch(t *testing.T) {
+	var got [16]byte
+	err := queryOne(t, nil, bytes.Repeat([]byte{1}, 15)).Scan(&got)
+	if !errors.Is(err, ErrRowsUnexpectedScan) {
+		t.Fatalf("got %v, want %v", err, ErrRowsUnexpectedScan)
+	}
+	if got != ([16]byte{}) {
+		t.Fatalf("got %x, want the destination untouched", got)
+	}
+}
+
+func TestScanNullTime(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		zero    bool
+		wantErr bool
+	}{
+		{"as zero", true, false},
+		{"as error", false, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+			err := queryOne(t, &ConnectionConfig{NullTimeAsZero: tc.zero}, nil).Scan(&got)
+			if tc.wantErr {
+				if !errors.Is(err, ErrRowsUnexpectedScan) {
+					t.Fatalf("got %v, want %v", err, ErrRowsUnexpectedScan)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("scan: %v", err)
+			}
+			if !got.IsZero() {
+				t.Fatalf("got %v, want the zero time", got)
+			}
+		})
+	}
+}
+
+func TestScanNullTimeIntoPointer(t *testing.T) {
+	at := time.Now()
+	got := &at
+	if err := queryOne(t, &ConnectionConfig{NullTimeAsZero: true}, nil).Scan(&got); err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	if got != nil {
+		t.Fatalf("got %v, want a nil *time.Time for NULL regardless of NullTimeAsZero", got)
+	}
+}
+
+// blobReader streams a BLOB, recording how many bytes were read from it.
+type blobReader struct {
+	r    io.Reader
+	read int
+}
+
+func (b *blobReader) Read(p []byte) (int, error) {
+	n, err := b.r.Read(p)
+	b.read += n
+	return n, err
+}
+
+func TestScanReaderStreaming(t *testing.T) {
+	blob := strings.Repeat("0123456789", 1<<16)
+	src := &blobReader{r: strings.NewReader(blob)}
+	var r io.Reader
+	if err := queryOne(t, nil, src).Scan(&r); err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	if src.read != 0 {
+		t.Fatalf("got %d bytes read by the scan, want the BLOB streamed on demand", src.read)
+	}
+	head := make([]byte, 10)
+	if _, err := io.ReadFull(r, head); err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if string(head) != "0123456789" || src.read >= len(blob) {
+		t.Fatalf("got %q with %d bytes read, want only the head of the BLOB read", head, src.read)
+	}
+	rest, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("read all: %v", err)
+	}
+	if len(head)+len(rest) != len(blob) {
+		t.Fatalf("got %d bytes, want %d", len(head)+len(rest), len(blob))
+	}
+}
+
+func TestScanReaderBytes(t *testing.T) {
+	b := []byte("blob")
+	rows := queryOne(t, nil, b)
+	var r io.Reader
+	if err := rows.Scan(&r); err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	// the driver may reuse the bytes once the next row is read
+	copy(b, "xxxx")
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("read all: %v", err)
+	}
+	if string(got) != "blob" {
+		t.Fatalf("got %q, want the bytes copied by the scan", got)
+	}
+}
+
+func TestScanReaderNull(t *testing.T) {
+	r := io.Reader(strings.NewReader("stale"))
+	if err := queryOne(t, nil, nil).Scan(&r); err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	if r != nil {
+		t.Fatalf("got %v, want a nil reader for NULL", r)
+	}
+}
+
+func TestRowsCloseJoinsStatementCloseError(t *testing.T) {
+	errStatement := errors.New("statement close failure")
+	errRows := errors.New("rows close failure")
+	for _, tc := range []struct {
+		name     string
+		rowsErr  error
+		wantRows bool
+	}{
+		{"statement only", nil, false},
+		{"rows and statement", errRows, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := &fakedriver.Driver{
+				PrepareOnly:    true,
+				CloseStatement: func(string) error { return errStatement },
+				Query: func(_ context.Context, _ *fakedriver.Conn, _ string, _ []driver.NamedValue) (driver.Rows, error) {
+					r := fakedriver.NewRows([]string{"v"}, []driver.Value{int64(1)})
+					r.CloseErr = tc.rowsErr
+					return r, nil
+				},
+			}
+			ctx := context.Background()
+			r, err := connectFake(t, d, nil).Query(ctx, "SELECT v")
+			if err != nil {
+				t.Fatalf("query: %v", err)
+			}
+			err = r.Close(ctx)
+			if !errors.Is(err, errStatement) {
+				t.Fatalf("got %v, want it to include %v", err, errStatement)
+			}
+			if errors.Is(err, errRows) != tc.wantRows {
+				t.Fatalf("got %v, want it to include %v: %t", err, errRows, tc.wantRows)
+			}
+			if n := d.StatementCloses.Load(); n != 1 {
+				t.Fatalf("got the statement closed %d times, want 1", n)
+			}
+		})
+	}
+}
+
+func TestRowsBufferAcrossResultSets(t *testing.T) {
+	c := connectFake(t, rowsDriver(func() *fakedriver.Rows {
+		return &fakedriver.Rows{Sets: []fakedriver.ResultSet{
+			{Columns: []string{"id", "name"}, Rows: [][]driver.Value{{int64(1), "a"}, {int64(2), "b"}}},
+			{Columns: []string{"id", "name"}, Rows: [][]driver.Value{{int64(3), "c"}}},
+			{Columns: []string{"id", "name", "email"}, Rows: [][]driver.Value{{int64(4), "d", "d@example.com"}}},
+			{Columns: []string{"n"}, Rows: [][]driver.Value{{int64(5)}}},
+		}}
+	}), nil)
+	ctx := context.Background()
+	r, err := c.Query(ctx, "CALL get_all()")
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	defer func() { _ = r.Close(ctx) }()
+
+	var got [][]any
+	for {
+		for r.Next(ctx) {
+			vs, err := r.Values()
+			if err != nil {
+				t.Fatalf("values: %v", err)
+			}
+			got = append(got, vs)
+		}
+		if !r.NextResultSet(ctx) {
+			break
+		}
+	}
+	if err = r.Error(); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	want := [][]any{
+		{int64(1), "a"}, {int64(2), "b"},
+		{int64(3), "c"},
+		{int64(4), "d", "d@example.com"},
+		{int64(5)},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if len(got[i]) != len(want[i]) {
+			t.Fatalf("got row %d %v, want %v", i, got[i], want[i])
+		}
+		for j := range want[i] {
+			if got[i][j] != want[i][j] {
+				t.Fatalf("got row %d %v, want %v", i, got[i], want[i])
+			}
+		}
+	}
+}
+
+func BenchmarkRowsFirstNext(b *testing.B) {
+	ctx := context.Background()
+	d := rowsDriver(func() *fakedriver.Rows {
+		return fakedriver.NewRows([]string{"id", "name", "email"}, []driver.Value{int64(1), "a", "a@example.com"})
+	})
+	name := "BenchmarkRowsFirstNext#" + strconv.FormatInt(fakeDrivers.Add(1), 10)
+	RegisterDriver(name, d)
+	defer DeregisterDriver(name)
+	db, err := Open(ctx, &ConnectionConfig{DriverName: name, URL: "fake"})
+	if err != nil {
+		b.Fatalf("open: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+	c, err := db.Connect(ctx)
+	if err != nil {
+		b.Fatalf("connect: %v", err)
+	}
+	defer func() { _ = c.Close() }()
+	r, err := c.Query(ctx, "SELECT id, name, email FROM users")
+	if err != nil {
+		b.Fatalf("query: %v", err)
+	}
+	_ = r.Close(ctx)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		r, err = c.Query(ctx, "SELECT id, name, email FROM users")
+		if err != nil {
+			b.Fatalf("query: %v", err)
+		}
+		// the columns are read from the driver on their own allocation, measured apart from the values
+		_ = r.Columns()
+		b.StartTimer()
+		// the buffer of the values is allocated by the query, so the first call to Next does not allocate it
+		if !r.Next(ctx) {
+			b.Fatalf("next: %v", r.Error())
+		}
+		b.StopTimer()
+		_ = r.Close(ctx)
+		b.StartTimer()
+	}
+}
+
+// errNotDrained is returned by the drainDriver connections queried before the rows of their last query were read.
+var errNotDrained = errors.New("connection busy: previous result not drained")
+
+// drainDriver returns a fake driver answering every query with three rows over two result sets, failing the queries
+// run on a connection whose previous rows were closed before being exhausted.
+func drainDriver() *fakedriver.Driver {
+	var mu sync.Mutex
+	last := make(map[int64]*fakedriver.Rows)
+	return &fakedriver.Driver{
+		Query: func(_ context.Context, c *fakedriver.Conn, _ string, _ []driver.NamedValue) (driver.Rows, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if r := last[c.ID]; r != nil && !r.Drained() {
+				return nil, errNotDrained
+			}
+			r := &fakedriver.Rows{Sets: []fakedriver.ResultSet{
+				{Columns: []string{"id"}, Rows: [][]driver.Value{{int64(1)}, {int64(2)}}},
+				{Columns: []string{"id"}, Rows: [][]driver.Value{{int64(3)}}},
+			}}
+			last[c.ID] = r
+			return r, nil
+		},
+	}
+}
+
+func TestRowsDrainOnClose(t *testing.T) {
+	tests := []struct {
+		name         string
+		drainOnClose bool
+		err          error
+	}{
+		{name: "disabled", err: errNotDrained},
+		{name: "enabled", drainOnClose: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := connectFake(t, drainDriver(), &ConnectionConfig{DrainOnClose: tt.drainOnClose})
+			ctx := context.Background()
+			r, err := c.Query(ctx, "SELECT id FROM users")
+			if err != nil {
+				t.Fatalf("first query: %v", err)
+			}
+			if !r.Next(ctx) {
+				t.Fatalf("next: %v", r.Error())
+			}
+			if err = r.Close(ctx); err != nil {
+				t.Fatalf("close: %v", err)
+			}
+
+			r, err = c.Query(ctx, "SELECT id FROM users")
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("got %v reusing the connection, want %v", err, tt.err)
+			}
+			if err == nil {
+				_ = r.Close(ctx)
+			}
+		})
+	}
+}
+
+func TestScanTextUnmarshaler(t *testing.T) {
+	for _, src := range []driver.Value{"192.168.0.1", []byte("192.168.0.1")} {
+		var addr netip.Addr
+		if err := queryOne(t, nil, src).Scan(&addr); err != nil {
+			t.Fatalf("%T: scan: %v", src, err)
+		}
+		if want := netip.MustParseAddr("192.168.0.1"); addr != want {
+			t.Fatalf("%T: got %v, want %v", src, addr, want)
+		}
+	}
+}
+
+func TestScanTextUnmarshalerError(t *testing.T) {
+	var addr netip.Addr
+	err := queryOne(t, nil, "not an address").Scan(&addr)
+	if !errors.Is(err, ErrRowsUnexpectedScan) || !strings.Contains(err.Error(), "not an address") {
+		t.Fatalf("got %v, want the error of unmarshaling the text", err)
+	}
+}
+
+// largeSlowRows returns rows taking a second to be read entirely.
+func largeSlowRows() *fakedriver.Rows {
+	rows := make([][]driver.Value, 1000)
+	for i := range rows {
+		rows[i] = []driver.Value{int64(i)}
+	}
+	r := fakedriver.NewRows([]string{"id"}, rows...)
+	r.NextDelay = time.Millisecond
+	return r
+}
+
+func TestRowsDrainOnCloseCancelled(t *testing.T) {
+	dr := largeSlowRows()
+	c := connectFake(t, rowsDriver(func() *fakedriver.Rows { return dr }), &ConnectionConfig{DrainOnClose: true})
+	r, err := c.Query(context.Background(), "SELECT id FROM users")
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	if !r.Next(context.Background()) {
+		t.Fatalf("next: %v", r.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = r.Close(ctx)
+	if !errors.Is(err, ErrBadConnection) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want %v wrapping %v", err, ErrBadConnection, context.DeadlineExceeded)
+	}
+	if d := time.Since(start); d > 500*time.Millisecond {
+		t.Fatalf("got the close taking %v, want the draining cut short", d)
+	}
+	if dr.Drained() || !dr.Closed() {
+		t.Fatalf("got the rows drained %t and closed %t, want them closed before being drained", dr.Drained(), dr.Closed())
+	}
+}
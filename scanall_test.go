@@ -0,0 +1,130 @@
+package alphasql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+)
+
+func TestScanAll(t *testing.T) {
+	at := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	var dr *fakedriver.Rows
+	c := connectFake(t, rowsDriver(func() *fakedriver.Rows {
+		dr = fakedriver.NewRows([]string{"id", "name", "created_at", "note"},
+			[]driver.Value{int64(1), "a", at, nil},
+			[]driver.Value{int64(2), []byte("b"), at.Add(time.Hour), "x"},
+			[]driver.Value{int64(3), "c", at.Add(2 * time.Hour), nil})
+		return dr
+	}), nil)
+	r, err := c.Query(context.Background(), "SELECT id, name, created_at, note FROM users")
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+
+	values, columns, err := r.ScanAll(context.Background())
+	if err != nil {
+		t.Fatalf("scan all: %v", err)
+	}
+	if want := []string{"id", "name", "created_at", "note"}; !reflect.DeepEqual(columns, want) {
+		t.Fatalf("got columns %v, want %v", columns, want)
+	}
+	want := [][]any{
+		{int64(1), "a", at, nil},
+		{int64(2), []byte("b"), at.Add(time.Hour), "x"},
+		{int64(3), "c", at.Add(2 * time.Hour), nil},
+	}
+	if !reflect.DeepEqual(values, want) {
+		t.Fatalf("got values %v, want %v", values, want)
+	}
+	if !dr.Closed() {
+		t.Fatal("got the rows left open, want them closed by scan all")
+	}
+}
+
+func TestScanAllEmpty(t *testing.T) {
+	c := connectFake(t, rowsDriver(func() *fakedriver.Rows {
+		return fakedriver.NewRows([]string{"id"})
+	}), nil)
+	r, err := c.Query(context.Background(), "SELECT id FROM users")
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	values, columns, err := r.ScanAll(context.Background())
+	if err != nil {
+		t.Fatalf("scan all: %v", err)
+	}
+	if len(values) != 0 || !reflect.DeepEqual(columns, []string{"id"}) {
+		t.Fatalf("got values %v and columns %v, want no values and the id column", values, columns)
+	}
+}
+
+func TestScanAllContextCancelled(t *testing.T) {
+	var dr *fakedriver.Rows
+	c := connectFake(t, rowsDriver(func() *fakedriver.Rows {
+		dr = fakedriver.NewRows([]string{"id"}, []driver.Value{int64(1)}, []driver.Value{int64(2)})
+		return dr
+	}), nil)
+	r, err := c.Query(context.Background(), "SELECT id FROM users")
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	values, _, err := r.ScanAll(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want %v", err, context.Canceled)
+	}
+	if values != nil {
+		t.Fatalf("got values %v, want none", values)
+	}
+	if !dr.Closed() {
+		t.Fatal("got the rows left open, want them closed by scan all")
+	}
+}
+
+// largeRowsDriver returns a fake driver answering every query with count rows of a 100 bytes each.
+func largeRowsDriver(count int) *fakedriver.Driver {
+	return rowsDriver(func() *fakedriver.Rows {
+		rows := make([][]driver.Value, count)
+		for i := range rows {
+			rows[i] = []driver.Value{make([]byte, 100)}
+		}
+		return fakedriver.NewRows([]string{"b"}, rows...)
+	})
+}
+
+func TestScanAllMaxBufferedBytes(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		limit int64
+		err   error
+	}{
+		{"no limit", 0, nil},
+		{"under the limit", 100 * 100, nil},
+		{"over the limit", 1000, ErrResultTooLarge},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := connectFake(t, largeRowsDriver(100), &ConnectionConfig{MaxBufferedBytes: tc.limit})
+			r, err := c.Query(context.Background(), "SELECT b FROM blobs")
+			if err != nil {
+				t.Fatalf("query: %v", err)
+			}
+			values, _, err := r.ScanAll(context.Background())
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("got %v, want %v", err, tc.err)
+			}
+			if tc.err == nil && len(values) != 100 {
+				t.Fatalf("got %d rows, want 100", len(values))
+			}
+			if tc.err != nil && values != nil {
+				t.Fatalf("got %d rows, want none once aborted", len(values))
+			}
+		})
+	}
+}
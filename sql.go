@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql/driver"
 	"errors"
+	"time"
 )
 
 // Ping verifies a Connection to the database is still alive,
@@ -19,8 +20,47 @@ func (c *Connection) Ping(ctx context.Context) error {
 	return nil
 }
 
+// PingWithRetry verifies a Connection to the database is still alive like [Connection.Ping], retrying up to
+// attempts times in total while it fails with [ErrBadConnection], waiting for backoff between the attempts,
+// for instance when probing a database that is still coming up. It returns the context error if the context
+// is done while waiting.
+func (c *Connection) PingWithRetry(ctx context.Context, attempts int, backoff time.Duration) error {
+	r := RetryPolicy{
+		MaxAttempts: attempts,
+		BaseDelay:   backoff,
+		MaxDelay:    backoff,
+		Retryable:   func(err error) bool { return errors.Is(err, ErrBadConnection) },
+	}
+	return r.Do(ctx, c.Ping)
+}
+
+// ResetSession resets the state of the session of the Connection, when the driver supports it through
+// [driver.SessionResetter], so no state leaks to the next user of the Connection. If the driver does not
+// support it, it is a noop. If the Connection is broken, [ErrBadConnection] is returned.
+func (c *Connection) ResetSession(ctx context.Context) error {
+	if sr, ok := c.c.(driver.SessionResetter); ok {
+		err := sr.ResetSession(ctx)
+		if errors.Is(err, driver.ErrBadConn) {
+			err = ErrBadConnection
+		}
+		return err
+	}
+	return nil
+}
+
+// IsValid reports whether the Connection is still valid, when the driver supports it through
+// [driver.Validator], allowing to discard a broken Connection without a round-trip to the database.
+// If the driver does not support it, the Connection is assumed to be valid.
+func (c *Connection) IsValid() bool {
+	if v, ok := c.c.(driver.Validator); ok {
+		return v.IsValid()
+	}
+	return true
+}
+
 // Query executes a query that returns rows, typically a SELECT.
 // The args are for any placeholder parameters in the query.
+// If the query fails, the error returned is a [*QueryError].
 func (c *Connection) Query(ctx context.Context, query string, args ...any) (Rows, error) {
 	r, s, err := c.query(ctx, query, args)
 	if errors.Is(err, driver.ErrBadConn) {
@@ -30,9 +70,9 @@ func (c *Connection) Query(ctx context.Context, query string, args ...any) (Rows
 		if s != nil {
 			_ = s.Close()
 		}
-		return nil, err
+		return nil, newQueryError(ctx, c.cfg, query, err)
 	}
-	rr := &rows{s: s, r: r}
+	rr := newRows(s, r, c.cfg)
 	return rr, nil
 }
 
@@ -43,7 +83,7 @@ func (c *Connection) Query(ctx context.Context, query string, args ...any) (Rows
 // Otherwise, [*Row.Scan] scans the first selected row and discards
 // the rest.
 func (c *Connection) QueryRow(ctx context.Context, query string, args ...any) Row {
-	r, err := c.Query(ctx, query, args)
+	r, err := c.Query(ctx, query, args...)
 	if errors.Is(err, driver.ErrBadConn) {
 		err = ErrBadConnection
 	}
@@ -52,6 +92,7 @@ func (c *Connection) QueryRow(ctx context.Context, query string, args ...any) Ro
 
 // Exec executes a query without returning any rows.
 // The args are for any placeholder parameters in the query.
+// If the query fails, the error returned is a [*QueryError].
 func (c *Connection) Exec(ctx context.Context, query string, args ...any) (Result, error) {
 	r, s, err := c.exec(ctx, query, args)
 	if s != nil {
@@ -61,7 +102,7 @@ func (c *Connection) Exec(ctx context.Context, query string, args ...any) (Resul
 		err = ErrBadConnection
 	}
 	if err != nil {
-		return nil, err
+		return nil, newQueryError(ctx, c.cfg, query, err)
 	}
 	return &result{r: r}, nil
 }
@@ -71,8 +112,18 @@ func (c *Connection) Exec(ctx context.Context, query string, args ...any) (Resul
 // returned statement.
 // The caller must call the statement's [Statement.Close] method
 // when the statement is no longer needed.
-func (c *Connection) Prepare(_ context.Context, _ string) (Statement, error) {
-	return nil, nil
+func (c *Connection) Prepare(ctx context.Context, query string) (Statement, error) {
+	if err := c.validateQuery(query); err != nil {
+		return nil, newQueryError(ctx, c.cfg, query, err)
+	}
+	s, err := getDriverStatement(ctx, c, query)
+	if errors.Is(err, driver.ErrBadConn) {
+		err = ErrBadConnection
+	}
+	if err != nil {
+		return nil, newQueryError(ctx, c.cfg, query, err)
+	}
+	return &statement{c: c, s: s, query: query}, nil
 }
 
 // BeginTX starts a transaction.
@@ -85,17 +136,24 @@ func (c *Connection) Prepare(_ context.Context, _ string) (Statement, error) {
 // The provided [TXOptions] is optional and may be nil if defaults should be used.
 // If a non-default isolation level is used that the driver doesn't support,
 // an error will be returned.
+//
+// Transactions cannot be nested, so if a transaction begun on the connection has not been
+// committed or rolled back yet, [ErrTransactionInProgress] is returned.
 func (c *Connection) BeginTX(ctx context.Context, options *TXOptions) (TX, error) {
 	options, err := validateAndDefaultTXOptions(options)
 	if err != nil {
 		return nil, err
 	}
+	if !c.inTX.CompareAndSwap(false, true) {
+		return nil, ErrTransactionInProgress
+	}
 	t, err := c.beginTX(ctx, options)
 	if errors.Is(err, driver.ErrBadConn) {
 		err = ErrBadConnection
 	}
 	if err != nil {
-		return nil, err
+		c.inTX.Store(false)
+		return nil, mapError(c.cfg.ErrorMap, err)
 	}
 	_, hasSessionReset := c.c.(driver.SessionResetter)
 	_, hasConnectionValidation := c.c.(driver.Validator)
@@ -103,7 +161,17 @@ func (c *Connection) BeginTX(ctx context.Context, options *TXOptions) (TX, error
 	return tt, nil
 }
 
+func (c *Connection) validateQuery(query string) error {
+	if c.cfg.MaxQueryLength > 0 && len(query) > c.cfg.MaxQueryLength {
+		return ErrQueryTooLong
+	}
+	return nil
+}
+
 func (c *Connection) query(ctx context.Context, query string, args []any) (driver.Rows, driver.Stmt, error) {
+	if err := c.validateQuery(query); err != nil {
+		return nil, nil, err
+	}
 	nvs, err := getDriverNamedValuesFromArgs(c, args)
 	if err != nil {
 		return nil, nil, err
@@ -119,6 +187,9 @@ func (c *Connection) query(ctx context.Context, query string, args []any) (drive
 }
 
 func (c *Connection) exec(ctx context.Context, query string, args []any) (driver.Result, driver.Stmt, error) {
+	if err := c.validateQuery(query); err != nil {
+		return nil, nil, err
+	}
 	nvs, err := getDriverNamedValuesFromArgs(c, args)
 	if err != nil {
 		return nil, nil, err
@@ -171,7 +242,6 @@ func queryUsingDriverStatement(ctx context.Context, s driver.Stmt, nvs []driver.
 	}
 	vs, err := getDriverValueFromDriverNamedValue(nvs)
 	if err != nil {
-		_ = s.Close()
 		return nil, err
 	}
 	select {
@@ -203,7 +273,6 @@ func execUsingDriverStatement(ctx context.Context, s driver.Stmt, nvs []driver.N
 	}
 	vs, err := getDriverValueFromDriverNamedValue(nvs)
 	if err != nil {
-		_ = s.Close()
 		return nil, err
 	}
 	select {
@@ -0,0 +1,106 @@
+package alphasql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+)
+
+func TestMaxQueryLength(t *testing.T) {
+	ctx := context.Background()
+	d := &fakedriver.Driver{}
+	c := connectFake(t, d, &ConnectionConfig{MaxQueryLength: 10})
+	atLimit := "SELECT 123"
+	overLimit := "SELECT 1234"
+
+	if _, err := c.Exec(ctx, atLimit); err != nil {
+		t.Fatalf("exec at the limit: %v", err)
+	}
+	r, err := c.Query(ctx, atLimit)
+	if err != nil {
+		t.Fatalf("query at the limit: %v", err)
+	}
+	_ = r.Close(ctx)
+
+	if _, err = c.Exec(ctx, overLimit); !errors.Is(err, ErrQueryTooLong) {
+		t.Fatalf("exec over the limit: got %v, want %v", err, ErrQueryTooLong)
+	}
+	if _, err = c.Query(ctx, overLimit); !errors.Is(err, ErrQueryTooLong) {
+		t.Fatalf("query over the limit: got %v, want %v", err, ErrQueryTooLong)
+	}
+	if _, err = c.Prepare(ctx, overLimit); !errors.Is(err, ErrQueryTooLong) {
+		t.Fatalf("prepare over the limit: got %v, want %v", err, ErrQueryTooLong)
+	}
+	if _, err = c.ExecNamed(ctx, "name", overLimit); !errors.Is(err, ErrQueryTooLong) {
+		t.Fatalf("exec named over the limit: got %v, want %v", err, ErrQueryTooLong)
+	}
+	if n := d.Prepares.Load(); n != 0 {
+		t.Fatalf("got %d statements prepared, want the queries over the limit rejected before the driver", n)
+	}
+}
+
+func TestMaxQueryLengthZeroMeansNoLimit(t *testing.T) {
+	c := connectFake(t, &fakedriver.Driver{}, nil)
+	if _, err := c.Exec(context.Background(), "SELECT "+strings.Repeat("1", 1<<16)); err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+}
+
+func TestQueryTimeoutScopedToTheQuery(t *testing.T) {
+	c := connectFake(t, &fakedriver.Driver{
+		Query: func(ctx context.Context, _ *fakedriver.Conn, query string, _ []driver.NamedValue) (driver.Rows, error) {
+			if query == "SELECT pg_sleep(1)" {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}
+			return fakedriver.NewRows([]string{"v"}, []driver.Value{int64(1)}), nil
+		},
+	}, nil)
+	parent, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	short, cancelShort := context.WithTimeout(parent, 10*time.Millisecond)
+	defer cancelShort()
+	if _, err := c.Query(short, "SELECT pg_sleep(1)"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want %v", err, context.DeadlineExceeded)
+	}
+	if err := parent.Err(); err != nil {
+		t.Fatalf("got the parent context done with %v, want it untouched by the timeout of its child", err)
+	}
+
+	r, err := c.Query(parent, "SELECT 1")
+	if err != nil {
+		t.Fatalf("query on the parent context: %v", err)
+	}
+	defer func() { _ = r.Close(parent) }()
+	if !r.Next(parent) {
+		t.Fatalf("next: %v", r.Error())
+	}
+}
+
+func TestExecNilResult(t *testing.T) {
+	ctx := context.Background()
+	for _, prepareOnly := range []bool{false, true} {
+		d := &fakedriver.Driver{
+			PrepareOnly: prepareOnly,
+			Exec: func(_ context.Context, _ *fakedriver.Conn, _ string, _ []driver.NamedValue) (driver.Result, error) {
+				return nil, nil
+			},
+		}
+		r, err := connectFake(t, d, nil).Exec(ctx, "CREATE TABLE users (id BIGINT)")
+		if err != nil {
+			t.Fatalf("prepare only %t: exec: %v", prepareOnly, err)
+		}
+		if _, err = r.LastInsertID(); !errors.Is(err, ErrResultUnavailable) {
+			t.Fatalf("prepare only %t: got %v for the last insert id, want %v", prepareOnly, err, ErrResultUnavailable)
+		}
+		if _, err = r.RowsAffected(); !errors.Is(err, ErrResultUnavailable) {
+			t.Fatalf("prepare only %t: got %v for the rows affected, want %v", prepareOnly, err, ErrResultUnavailable)
+		}
+	}
+}
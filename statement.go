@@ -3,6 +3,8 @@ package alphasql
 import (
 	"context"
 	"database/sql/driver"
+	"errors"
+	"sync/atomic"
 )
 
 // Statement is a prepared statement.
@@ -12,13 +14,81 @@ import (
 // underlying connection forever. If the [TX] or [Connection] closes, the Statement will
 // become unusable and all operations will return an error.
 type Statement interface {
+	// Close closes the statement. Close is idempotent, and any call after the first one returns nil.
 	Close(ctx context.Context) error
+
 	NumberOfInputs() int
 	Exec(ctx context.Context, args ...any) (Result, error)
+
+	// Query executes the prepared statement with the given arguments, returning the rows selected.
+	// Unlike the rows returned by [Connection.Query], closing the rows leaves the statement open,
+	// so it can be queried again, until [Statement.Close] is called.
 	Query(ctx context.Context, args ...any) (Rows, error)
+
+	// QueryRow executes the prepared statement with the given arguments, expecting at most one row.
+	// Like [Statement.Query], scanning the row leaves the statement open.
 	QueryRow(ctx context.Context, args ...any) (Row, error)
 }
 
 type statement struct {
-	s driver.Stmt
+	c      *Connection
+	s      driver.Stmt
+	query  string
+	closed atomic.Bool
+}
+
+func (s *statement) Close(_ context.Context) error {
+	if s.closed.CompareAndSwap(false, true) {
+		return mapError(s.c.cfg.ErrorMap, s.s.Close())
+	}
+	return nil
+}
+
+func (s *statement) NumberOfInputs() int {
+	return s.s.NumInput()
+}
+
+func (s *statement) Exec(ctx context.Context, args ...any) (Result, error) {
+	if s.closed.Load() {
+		return nil, ErrStatementClosed
+	}
+	nvs, err := getDriverNamedValuesFromArgs(s.c, args)
+	if err != nil {
+		return nil, newQueryError(ctx, s.c.cfg, s.query, err)
+	}
+	r, err := execUsingDriverStatement(ctx, s.s, nvs)
+	if errors.Is(err, driver.ErrBadConn) {
+		err = ErrBadConnection
+	}
+	if err != nil {
+		return nil, newQueryError(ctx, s.c.cfg, s.query, err)
+	}
+	return &result{r: r}, nil
+}
+
+func (s *statement) Query(ctx context.Context, args ...any) (Rows, error) {
+	if s.closed.Load() {
+		return nil, ErrStatementClosed
+	}
+	nvs, err := getDriverNamedValuesFromArgs(s.c, args)
+	if err != nil {
+		return nil, newQueryError(ctx, s.c.cfg, s.query, err)
+	}
+	r, err := queryUsingDriverStatement(ctx, s.s, nvs)
+	if errors.Is(err, driver.ErrBadConn) {
+		err = ErrBadConnection
+	}
+	if err != nil {
+		return nil, newQueryError(ctx, s.c.cfg, s.query, err)
+	}
+	// the driver statement is owned by the statement, so it is not closed along with the rows
+	return newRows(nil, r, s.c.cfg), nil
+}
+
+func (s *statement) QueryRow(ctx context.Context, args ...any) (Row, error) {
+	r, err := s.Query(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	return &row{r: r}, nil
 }
@@ -0,0 +1,86 @@
+package alphasql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+)
+
+func TestStatementCloseTwice(t *testing.T) {
+	var closed atomic.Bool
+	d := &fakedriver.Driver{CloseStatement: func(_ string) error {
+		if !closed.CompareAndSwap(false, true) {
+			return errors.New("statement already closed")
+		}
+		return nil
+	}}
+	ctx := context.Background()
+	s, err := connectFake(t, d, nil).Prepare(ctx, "SELECT 1")
+	if err != nil {
+		t.Fatalf("prepare: %v", err)
+	}
+	if err = s.Close(ctx); err != nil {
+		t.Fatalf("first close: %v", err)
+	}
+	if err = s.Close(ctx); err != nil {
+		t.Fatalf("second close: %v", err)
+	}
+	if n := d.StatementCloses.Load(); n != 1 {
+		t.Fatalf("got %d driver statement closes, want 1", n)
+	}
+	if _, err = s.Exec(ctx); !errors.Is(err, ErrStatementClosed) {
+		t.Fatalf("got %v, want %v", err, ErrStatementClosed)
+	}
+}
+
+func TestStatementQueryTwice(t *testing.T) {
+	d := &fakedriver.Driver{Query: func(_ context.Context, _ *fakedriver.Conn, _ string, args []driver.NamedValue) (driver.Rows, error) {
+		return fakedriver.NewRows([]string{"name"}, []driver.Value{"user " + strconv.FormatInt(args[0].Value.(int64), 10)}), nil
+	}}
+	ctx := context.Background()
+	c := connectFake(t, d, nil)
+	prepares := d.Prepares.Load()
+	s, err := c.Prepare(ctx, "SELECT name FROM users WHERE id = $1")
+	if err != nil {
+		t.Fatalf("prepare: %v", err)
+	}
+	defer func() { _ = s.Close(ctx) }()
+
+	for _, id := range []int64{1, 2} {
+		r, err := s.Query(ctx, id)
+		if err != nil {
+			t.Fatalf("query %d: %v", id, err)
+		}
+		var name string
+		if !r.Next(ctx) {
+			t.Fatalf("query %d: got no rows, want one", id)
+		}
+		if err = r.Scan(&name); err != nil {
+			t.Fatalf("query %d: scan: %v", id, err)
+		}
+		if err = r.Close(ctx); err != nil {
+			t.Fatalf("query %d: close: %v", id, err)
+		}
+		if want := "user " + strconv.FormatInt(id, 10); name != want {
+			t.Fatalf("query %d: got %q, want %q", id, name, want)
+		}
+		if n := d.StatementCloses.Load(); n != 0 {
+			t.Fatalf("query %d: got %d driver statement closes after closing the rows, want 0", id, n)
+		}
+	}
+	if n := d.Prepares.Load() - prepares; n != 1 {
+		t.Fatalf("got %d statements prepared, want 1", n)
+	}
+
+	if err = s.Close(ctx); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if n := d.StatementCloses.Load(); n != 1 {
+		t.Fatalf("got %d driver statement closes, want 1", n)
+	}
+}
@@ -0,0 +1,60 @@
+package alphasql
+
+import "context"
+
+// RowValues is the set of values of a row, in the order of the columns of the result set.
+type RowValues []any
+
+// QueryChan executes a query that returns rows, streaming the values of each row on the returned
+// channel of rows. Both the channels are closed once all the rows have been sent or on failure, in which
+// case the error is sent on the returned channel of errors first.
+//
+// The rows are closed when the context is done, so the consumer can stop reading early by cancelling it.
+func (c *Connection) QueryChan(ctx context.Context, query string, args ...any) (<-chan RowValues, <-chan error) {
+	values := make(chan RowValues)
+	errs := make(chan error, 1)
+	r, err := c.Query(ctx, query, args...)
+	if err != nil {
+		errs <- err
+		close(values)
+		close(errs)
+		return values, errs
+	}
+	go streamRows(ctx, r, values, errs)
+	return values, errs
+}
+
+func streamRows(ctx context.Context, r Rows, values chan<- RowValues, errs chan<- error) {
+	defer close(errs)
+	defer close(values)
+	defer func() { _ = r.Close(ctx) }()
+	for r.Next(ctx) {
+		vs, err := scanRowValues(r)
+		if err != nil {
+			errs <- err
+			return
+		}
+		select {
+		case values <- vs:
+		case <-ctx.Done():
+			errs <- ctx.Err()
+			return
+		}
+	}
+	if err := r.Error(); err != nil {
+		errs <- err
+	}
+}
+
+// scanRowValues scans the values of the current row.
+func scanRowValues(r Rows) (RowValues, error) {
+	vs := make(RowValues, len(r.Columns()))
+	dest := make([]any, len(vs))
+	for i := range vs {
+		dest[i] = &vs[i]
+	}
+	if err := r.Scan(dest...); err != nil {
+		return nil, err
+	}
+	return vs, nil
+}
@@ -0,0 +1,78 @@
+package alphasql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+)
+
+func countingRows(n int) *fakedriver.Rows {
+	rows := make([][]driver.Value, n)
+	for i := range rows {
+		rows[i] = []driver.Value{int64(i), "v"}
+	}
+	return fakedriver.NewRows([]string{"n", "s"}, rows...)
+}
+
+func TestQueryChan(t *testing.T) {
+	var fr *fakedriver.Rows
+	c := connectFake(t, rowsDriver(func() *fakedriver.Rows {
+		fr = countingRows(5)
+		return fr
+	}), nil)
+	values, errs := c.QueryChan(context.Background(), "SELECT n, s")
+	var i int64
+	for vs := range values {
+		if len(vs) != 2 || vs[0] != i || vs[1] != "v" {
+			t.Fatalf("got row %v, want [%d v]", vs, i)
+		}
+		i++
+	}
+	if i != 5 {
+		t.Fatalf("got %d rows, want 5", i)
+	}
+	if err, ok := <-errs; ok {
+		t.Fatalf("got error %v, want the errors channel closed", err)
+	}
+	if !fr.Closed() {
+		t.Fatal("got the rows open after the stream completed")
+	}
+}
+
+func TestQueryChanCancelledEarly(t *testing.T) {
+	var fr *fakedriver.Rows
+	c := connectFake(t, rowsDriver(func() *fakedriver.Rows {
+		fr = countingRows(100)
+		return fr
+	}), nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	values, errs := c.QueryChan(ctx, "SELECT n, s")
+	if vs := <-values; len(vs) != 2 || vs[0] != int64(0) {
+		t.Fatalf("got row %v, want the first row", vs)
+	}
+	cancel()
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want %v", err, context.Canceled)
+	}
+	for range values {
+	}
+	if !fr.Closed() {
+		t.Fatal("got the rows open after the consumer stopped")
+	}
+	if fr.Drained() {
+		t.Fatal("got all the rows read after the consumer stopped")
+	}
+}
+
+func TestQueryChanQueryError(t *testing.T) {
+	values, errs := connectFake(t, failingDriver(errDriver), nil).QueryChan(context.Background(), "SELECT n, s")
+	if err := <-errs; !errors.Is(err, errDriver) {
+		t.Fatalf("got %v, want %v", err, errDriver)
+	}
+	if _, ok := <-values; ok {
+		t.Fatal("got a row, want the values channel closed")
+	}
+}
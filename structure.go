@@ -0,0 +1,310 @@
+package alphasql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// structureTag is the struct tag used to map a column to a field of a structure.
+const structureTag = "db"
+
+type structureField struct {
+	index []int
+	// json is set for the fields tagged with the json option, whose column holds a JSON document to be
+	// unmarshaled into the field.
+	json bool
+	// ambiguous is set when several fields have the name at the shallowest depth it is found, none of them being
+	// mapped, like the ambiguous selectors of Go.
+	ambiguous bool
+}
+
+type structureFields struct {
+	byName      map[string]structureField
+	byLowerName map[string]structureField
+}
+
+// structuresFields caches the fields of the structures scanned into, keyed by their type.
+var structuresFields sync.Map
+
+// discard is used as the scan destination of the columns not mapped to any field.
+type discard struct{}
+
+func (discard) Scan(_ any) error {
+	return nil
+}
+
+// jsonField is used as the scan destination of the columns mapped to the fields tagged with the json option.
+type jsonField struct {
+	dest any
+}
+
+func (j jsonField) Scan(src any) error {
+	switch s := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(s, j.dest)
+	case string:
+		return json.Unmarshal([]byte(s), j.dest)
+	}
+	return fmt.Errorf("converting driver.Value type %T to a JSON field: %w", src, ErrRowsUnsupportedScan)
+}
+
+// QueryStruct executes a query that is expected to return at most one row, and scans the row
+// into dest, which must be a pointer to a structure. The columns are mapped to the fields using
+// the `db` struct tag, falling back to a case-insensitive match of the field name.
+// A column holding a JSON document can be unmarshaled into a field, such as a nested structure,
+// by tagging it with the json option, for instance `db:"meta,json"`.
+// For the one-off queries, dest may also point to an anonymous structure declared inline, such as
+// &struct{ ID int64 `db:"id"` }{}, whose fields are mapped the same way.
+// If the query selects no rows, [ErrNoRows] is returned.
+func (c *Connection) QueryStruct(ctx context.Context, dest any, query string, args ...any) error {
+	r, err := c.Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = r.Close(ctx) }()
+	if !r.Next(ctx) {
+		if err = r.Error(); err != nil {
+			return err
+		}
+		return ErrNoRows
+	}
+	err = ScanStructure(r.Scan, r.Columns(), dest)
+	if err != nil {
+		return err
+	}
+	return r.Close(ctx)
+}
+
+// QueryStructs executes a query that returns rows, and scans all the rows into dest, which must be
+// a pointer to a slice of structures or of pointers to structures. The columns are mapped to the fields
+// the same way as [Connection.QueryStruct]. If the query selects no rows, dest is set to an empty slice.
+// For the queries selecting a single column, the elements may also be of any type implementing [Scanner]
+// with a pointer receiver, see [ScanStructure].
+func (c *Connection) QueryStructs(ctx context.Context, dest any, query string, args ...any) error {
+	dv := reflect.ValueOf(dest)
+	if dv.Kind() != reflect.Pointer {
+		return ErrNotAPointer
+	}
+	if dv.IsNil() {
+		return ErrNilPointer
+	}
+	sv := dv.Elem()
+	if sv.Kind() != reflect.Slice {
+		return ErrRowsUnsupportedScan
+	}
+	et := sv.Type().Elem()
+	isPointer := et.Kind() == reflect.Pointer
+	if isPointer {
+		et = et.Elem()
+	}
+	r, err := c.Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = r.Close(ctx) }()
+	result := reflect.MakeSlice(sv.Type(), 0, 0)
+	for r.Next(ctx) {
+		e := reflect.New(et)
+		err = ScanStructure(r.Scan, r.Columns(), e.Interface())
+		if err != nil {
+			return err
+		}
+		if isPointer {
+			result = reflect.Append(result, e)
+		} else {
+			result = reflect.Append(result, e.Elem())
+		}
+	}
+	if err = r.Error(); err != nil {
+		return err
+	}
+	sv.Set(result)
+	return r.Close(ctx)
+}
+
+// ScanStructure scans the current row into dest, which must be a pointer to a structure.
+// It builds the pointers to the fields mapped to the columns in the column order, the same way
+// as [Connection.QueryStruct], and passes them to scan. The columns not mapped to any field are
+// scanned into a sink discarding their values.
+//
+// If dest implements [Scanner] and the row has a single column, the column is scanned into dest
+// through its Scan method instead, so the rows can also be collected into the user types, such as
+// custom aggregates, which are not structures.
+//
+// It returns [ErrNilPointer] if dest is nil, and [ErrNotAPointer] if dest is not a pointer.
+func ScanStructure(scan func(values ...any) error, columns []Column, dest any) error {
+	if dest == nil {
+		return ErrNilPointer
+	}
+	dv := reflect.ValueOf(dest)
+	if dv.Kind() != reflect.Pointer {
+		return ErrNotAPointer
+	}
+	if dv.IsNil() {
+		return ErrNilPointer
+	}
+	if _, ok := dest.(Scanner); ok && len(columns) == 1 {
+		return scan(dest)
+	}
+	sv := dv.Elem()
+	if sv.Kind() != reflect.Struct {
+		return ErrRowsUnsupportedScan
+	}
+	fields := getStructureFields(sv.Type())
+	values := make([]any, len(columns))
+	for i := range columns {
+		f, ok := fields.lookup(columns[i].Name())
+		if !ok {
+			values[i] = discard{}
+			continue
+		}
+		values[i] = sv.FieldByIndex(f.index).Addr().Interface()
+		if f.json {
+			values[i] = jsonField{dest: values[i]}
+		}
+	}
+	return scan(values...)
+}
+
+// StructureArgs returns the fields of v, which must be a structure or a pointer to a structure, as [NamedArg]
+// values to be passed as the args of a query binding the named parameters, like @name. The fields are named
+// using the `db` struct tag the same way as [Connection.QueryStruct], the fields of the embedded structures being
+// promoted like in Go, and the fields tagged with the json option are marshaled into a JSON document.
+//
+// It returns [ErrNilPointer] if v is nil, and [ErrNotAStructure] if v is not a structure.
+func StructureArgs(v any) ([]any, error) {
+	if v == nil {
+		return nil, ErrNilPointer
+	}
+	sv := reflect.ValueOf(v)
+	if sv.Kind() == reflect.Pointer {
+		if sv.IsNil() {
+			return nil, ErrNilPointer
+		}
+		sv = sv.Elem()
+	}
+	if sv.Kind() != reflect.Struct {
+		return nil, ErrNotAStructure
+	}
+	return appendStructureArgs(nil, getStructureFields(sv.Type()), sv, nil)
+}
+
+func appendStructureArgs(args []any, fs *structureFields, sv reflect.Value, index []int) ([]any, error) {
+	t := sv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, hasTag := f.Tag.Lookup(structureTag)
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+		fi := append(index[:len(index):len(index)], i)
+		if f.Anonymous && !hasTag && f.Type.Kind() == reflect.Struct {
+			var err error
+			args, err = appendStructureArgs(args, fs, sv.Field(i), fi)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		// the fields hidden by a shallower one, or ambiguous, are left out as they are when scanning
+		if sf := fs.byName[name]; sf.ambiguous || len(sf.index) != len(fi) {
+			continue
+		}
+		value := sv.Field(i).Interface()
+		if hasStructureTagOption(options, "json") {
+			b, err := json.Marshal(value)
+			if err != nil {
+				return nil, err
+			}
+			value = b
+		}
+		args = append(args, Named(name, value))
+	}
+	return args, nil
+}
+
+func getStructureFields(t reflect.Type) *structureFields {
+	if fs, ok := structuresFields.Load(t); ok {
+		return fs.(*structureFields)
+	}
+	fs := &structureFields{
+		byName:      make(map[string]structureField),
+		byLowerName: make(map[string]structureField),
+	}
+	fs.add(t, nil)
+	structuresFields.Store(t, fs)
+	return fs
+}
+
+func (fs *structureFields) add(t reflect.Type, index []int) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, hasTag := f.Tag.Lookup(structureTag)
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+		fi := make([]int, len(index)+1)
+		copy(fi, index)
+		fi[len(index)] = i
+		// the fields of the embedded structures are promoted, unless the embedded structure is tagged itself
+		if f.Anonymous && !hasTag && f.Type.Kind() == reflect.Struct {
+			fs.add(f.Type, fi)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		sf := structureField{index: fi, json: hasStructureTagOption(options, "json")}
+		addStructureField(fs.byName, name, sf)
+		addStructureField(fs.byLowerName, strings.ToLower(name), sf)
+	}
+}
+
+// addStructureField maps the name to the field following the promotion rules of Go, the shallower fields hiding the
+// deeper ones whatever their order, and the fields of the same depth making the name ambiguous.
+func addStructureField(fields map[string]structureField, name string, sf structureField) {
+	existing, ok := fields[name]
+	switch {
+	case !ok || len(sf.index) < len(existing.index):
+		fields[name] = sf
+	case len(sf.index) == len(existing.index):
+		existing.ambiguous = true
+		fields[name] = existing
+	}
+}
+
+func hasStructureTagOption(options, option string) bool {
+	for options != "" {
+		var o string
+		o, options, _ = strings.Cut(options, ",")
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+func (fs *structureFields) lookup(column string) (structureField, bool) {
+	if f, ok := fs.byName[column]; ok {
+		return f, !f.ambiguous
+	}
+	f, ok := fs.byLowerName[strings.ToLower(column)]
+	return f, ok && !f.ambiguous
+}
@@ -0,0 +1,212 @@
+package alphasql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+)
+
+type structureUser struct {
+	ID    int64 `db:"id"`
+	Name  string
+	Email string `db:"email_address"`
+}
+
+func structureUsersDriver(rows ...[]driver.Value) *fakedriver.Driver {
+	return rowsDriver(func() *fakedriver.Rows {
+		return fakedriver.NewRows([]string{"id", "NAME", "email_address", "ignored"}, rows...)
+	})
+}
+
+var structureUsersRows = [][]driver.Value{
+	{int64(1), "a", "a@example.com", "x"},
+	{int64(2), "b", "b@example.com", "y"},
+}
+
+func TestQueryStruct(t *testing.T) {
+	c := connectFake(t, structureUsersDriver(structureUsersRows...), nil)
+	var u structureUser
+	if err := c.QueryStruct(context.Background(), &u, "SELECT * FROM users"); err != nil {
+		t.Fatalf("query struct: %v", err)
+	}
+	if want := (structureUser{ID: 1, Name: "a", Email: "a@example.com"}); u != want {
+		t.Fatalf("got %+v, want %+v", u, want)
+	}
+}
+
+func TestQueryStructNoRows(t *testing.T) {
+	c := connectFake(t, structureUsersDriver(), nil)
+	var u structureUser
+	if err := c.QueryStruct(context.Background(), &u, "SELECT * FROM users"); !errors.Is(err, ErrNoRows) {
+		t.Fatalf("got %v, want %v", err, ErrNoRows)
+	}
+}
+
+func TestQueryStructAnonymous(t *testing.T) {
+	c := connectFake(t, structureUsersDriver(structureUsersRows...), nil)
+	var u struct {
+		ID    int64  `db:"id"`
+		Email string `db:"email_address"`
+	}
+	if err := c.QueryStruct(context.Background(), &u, "SELECT * FROM users"); err != nil {
+		t.Fatalf("query struct: %v", err)
+	}
+	if u.ID != 1 || u.Email != "a@example.com" {
+		t.Fatalf("got %+v, want the first user", u)
+	}
+
+	var us []struct {
+		Name string
+	}
+	if err := c.QueryStructs(context.Background(), &us, "SELECT * FROM users"); err != nil {
+		t.Fatalf("query structs: %v", err)
+	}
+	if len(us) != 2 || us[0].Name != "a" || us[1].Name != "b" {
+		t.Fatalf("got %+v, want the names of both users", us)
+	}
+}
+
+func TestQueryStructs(t *testing.T) {
+	want := []structureUser{{ID: 1, Name: "a", Email: "a@example.com"}, {ID: 2, Name: "b", Email: "b@example.com"}}
+	c := connectFake(t, structureUsersDriver(structureUsersRows...), nil)
+
+	var us []structureUser
+	if err := c.QueryStructs(context.Background(), &us, "SELECT * FROM users"); err != nil {
+		t.Fatalf("query structs: %v", err)
+	}
+	if len(us) != 2 || us[0] != want[0] || us[1] != want[1] {
+		t.Fatalf("got %+v, want %+v", us, want)
+	}
+
+	var ps []*structureUser
+	if err := c.QueryStructs(context.Background(), &ps, "SELECT * FROM users"); err != nil {
+		t.Fatalf("query structs: %v", err)
+	}
+	if len(ps) != 2 || *ps[0] != want[0] || *ps[1] != want[1] {
+		t.Fatalf("got %+v, want %+v", ps, want)
+	}
+}
+
+func TestQueryStructsNoRows(t *testing.T) {
+	c := connectFake(t, structureUsersDriver(), nil)
+	var us []structureUser
+	if err := c.QueryStructs(context.Background(), &us, "SELECT * FROM users"); err != nil {
+		t.Fatalf("query structs: %v", err)
+	}
+	if us == nil || len(us) != 0 {
+		t.Fatalf("got %#v, want an empty slice", us)
+	}
+}
+
+type structureProfile struct {
+	ID   int64 `db:"id"`
+	Meta struct {
+		Age     int `json:"age"`
+		Address struct {
+			City string `json:"city"`
+		} `json:"address"`
+		Tags []string `json:"tags"`
+	} `db:"meta,json"`
+}
+
+func TestQueryStructJSONColumn(t *testing.T) {
+	c := connectFake(t, rowsDriver(func() *fakedriver.Rows {
+		return fakedriver.NewRows([]string{"id", "meta"},
+			[]driver.Value{int64(7), []byte(`{"age":42,"address":{"city":"Pune"},"tags":["a","b"]}`)})
+	}), nil)
+	var p structureProfile
+	if err := c.QueryStruct(context.Background(), &p, "SELECT id, meta FROM profiles"); err != nil {
+		t.Fatalf("query struct: %v", err)
+	}
+	if p.ID != 7 || p.Meta.Age != 42 || p.Meta.Address.City != "Pune" || len(p.Meta.Tags) != 2 || p.Meta.Tags[1] != "b" {
+		t.Fatalf("got %+v", p)
+	}
+}
+
+func TestQueryStructJSONColumnNull(t *testing.T) {
+	c := connectFake(t, rowsDriver(func() *fakedriver.Rows {
+		return fakedriver.NewRows([]string{"id", "meta"}, []driver.Value{int64(7), nil})
+	}), nil)
+	var p structureProfile
+	if err := c.QueryStruct(context.Background(), &p, "SELECT id, meta FROM profiles"); err != nil {
+		t.Fatalf("query struct: %v", err)
+	}
+	if p.ID != 7 || p.Meta.Age != 0 || p.Meta.Tags != nil {
+		t.Fatalf("got %+v, want the JSON field left to its zero value", p)
+	}
+}
+
+func TestQueryStructJSONColumnInvalid(t *testing.T) {
+	c := connectFake(t, rowsDriver(func() *fakedriver.Rows {
+		return fakedriver.NewRows([]string{"id", "meta"}, []driver.Value{int64(7), int64(1)})
+	}), nil)
+	var p structureProfile
+	err := c.QueryStruct(context.Background(), &p, "SELECT id, meta FROM profiles")
+	if !errors.Is(err, ErrRowsUnsupportedScan) {
+		t.Fatalf("got %v, want %v", err, ErrRowsUnsupportedScan)
+	}
+}
+
+type structureBase struct {
+	ID   int64 `db:"id"`
+	Name string
+}
+
+type structureAudit struct {
+	Name string
+}
+
+// structureAccount embeds structureBase before declaring its own id, which hides the one of structureBase.
+type structureAccount struct {
+	structureBase
+	ID int64 `db:"id"`
+}
+
+// structureAmbiguous embeds two structures having a name at the same depth, which is mapped to neither.
+type structureAmbiguous struct {
+	structureBase
+	structureAudit
+}
+
+func TestQueryStructEmbedded(t *testing.T) {
+	c := connectFake(t, structureUsersDriver(structureUsersRows...), nil)
+	ctx := context.Background()
+
+	var a structureAccount
+	if err := c.QueryStruct(ctx, &a, "SELECT * FROM users"); err != nil {
+		t.Fatalf("query struct: %v", err)
+	}
+	if want := (structureAccount{structureBase: structureBase{Name: "a"}, ID: 1}); a != want {
+		t.Fatalf("got %+v, want %+v with the outer id set", a, want)
+	}
+
+	var m structureAmbiguous
+	if err := c.QueryStruct(ctx, &m, "SELECT * FROM users"); err != nil {
+		t.Fatalf("query struct: %v", err)
+	}
+	if want := (structureAmbiguous{structureBase: structureBase{ID: 1}}); m != want {
+		t.Fatalf("got %+v, want %+v with the ambiguous name left unset", m, want)
+	}
+}
+
+func TestStructureArgsEmbedded(t *testing.T) {
+	args, err := StructureArgs(structureAccount{structureBase: structureBase{ID: 1, Name: "a"}, ID: 2})
+	if err != nil {
+		t.Fatalf("structure args: %v", err)
+	}
+	want := []any{Named("Name", "a"), Named("id", int64(2))}
+	if !reflect.DeepEqual(args, want) {
+		t.Fatalf("got %v, want %v", args, want)
+	}
+
+	if args, err = StructureArgs(structureAmbiguous{}); err != nil {
+		t.Fatalf("structure args: %v", err)
+	}
+	if want = []any{Named("id", int64(0))}; !reflect.DeepEqual(args, want) {
+		t.Fatalf("got %v, want %v without the ambiguous name", args, want)
+	}
+}
@@ -3,6 +3,7 @@ package alphasql
 import (
 	"context"
 	"database/sql/driver"
+	"fmt"
 )
 
 // TXIsolationLevel is the transaction isolation level used in [TXOptions].
@@ -57,6 +58,18 @@ type TX interface {
 	Prepare(ctx context.Context, query string) (Statement, error)
 	Statement(ctx context.Context, s Statement) (Statement, error)
 	KeepConnectionOnRollback() bool
+
+	// Savepoint creates a savepoint with the given name within the transaction, allowing a partial rollback
+	// to it with [TX.RollbackToSavepoint]. The name must be a simple identifier, made of letters, digits and
+	// underscores, and not starting with a digit, otherwise [ErrInvalidSavepointName] is returned.
+	Savepoint(ctx context.Context, name string) error
+
+	// RollbackToSavepoint rolls back the changes made since the savepoint with the given name was created,
+	// keeping the transaction in progress.
+	RollbackToSavepoint(ctx context.Context, name string) error
+
+	// ReleaseSavepoint releases the savepoint with the given name, keeping the changes made since it was created.
+	ReleaseSavepoint(ctx context.Context, name string) error
 }
 
 type tx struct {
@@ -71,7 +84,8 @@ func (t *tx) Commit(_ context.Context) error {
 		return ErrTXClosed
 	}
 	t.closed = true
-	return t.t.Commit()
+	defer t.c.inTX.Store(false)
+	return mapError(t.c.cfg.ErrorMap, t.t.Commit())
 }
 
 func (t *tx) Rollback(_ context.Context) error {
@@ -79,7 +93,8 @@ func (t *tx) Rollback(_ context.Context) error {
 		return ErrTXClosed
 	}
 	t.closed = true
-	return t.t.Rollback()
+	defer t.c.inTX.Store(false)
+	return mapError(t.c.cfg.ErrorMap, t.t.Rollback())
 }
 
 func (t *tx) Query(ctx context.Context, query string, args ...any) (Rows, error) {
@@ -94,8 +109,8 @@ func (t *tx) Exec(ctx context.Context, query string, args ...any) (Result, error
 	return t.c.Exec(ctx, query, args...)
 }
 
-func (t *tx) Prepare(_ context.Context, _ string) (Statement, error) {
-	return nil, nil
+func (t *tx) Prepare(ctx context.Context, query string) (Statement, error) {
+	return t.c.Prepare(ctx, query)
 }
 
 func (t *tx) Statement(_ context.Context, _ Statement) (Statement, error) {
@@ -106,6 +121,44 @@ func (t *tx) KeepConnectionOnRollback() bool {
 	return t.keepConnectionOnRollback
 }
 
+func (t *tx) Savepoint(ctx context.Context, name string) error {
+	return t.execSavepoint(ctx, "SAVEPOINT ", name)
+}
+
+func (t *tx) RollbackToSavepoint(ctx context.Context, name string) error {
+	return t.execSavepoint(ctx, "ROLLBACK TO SAVEPOINT ", name)
+}
+
+func (t *tx) ReleaseSavepoint(ctx context.Context, name string) error {
+	return t.execSavepoint(ctx, "RELEASE SAVEPOINT ", name)
+}
+
+func (t *tx) execSavepoint(ctx context.Context, statement, name string) error {
+	if t.closed {
+		return ErrTXClosed
+	}
+	if err := validateSavepointName(name); err != nil {
+		return err
+	}
+	_, err := t.c.Exec(ctx, statement+name)
+	return err
+}
+
+// validateSavepointName makes sure the name is a simple identifier, as it is interpolated into the statements.
+func validateSavepointName(name string) error {
+	if name == "" {
+		return fmt.Errorf("%w: %q", ErrInvalidSavepointName, name)
+	}
+	for i, r := range name {
+		isLetter := r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r == '_'
+		isDigit := r >= '0' && r <= '9'
+		if !isLetter && (i == 0 || !isDigit) {
+			return fmt.Errorf("%w: %q", ErrInvalidSavepointName, name)
+		}
+	}
+	return nil
+}
+
 func validateAndDefaultTXOptions(options *TXOptions) (*TXOptions, error) {
 	if options == nil {
 		options = &TXOptions{
@@ -114,10 +167,10 @@ func validateAndDefaultTXOptions(options *TXOptions) (*TXOptions, error) {
 		}
 	}
 	if options.IsolationLevel < TXIsolationLevelDefault || options.IsolationLevel > TXIsolationLevelLinearizable {
-		return nil, ErrTXOptionsInvalidIsolationLevel
+		return nil, fmt.Errorf("%w: %d", ErrTXOptionsInvalidIsolationLevel, options.IsolationLevel)
 	}
 	if options.AccessMode != TXAccessModeReadWrite && options.AccessMode != TXAccessModeReadOnly {
-		return nil, ErrTXOptionsInvalidAccessMode
+		return nil, fmt.Errorf("%w: %q", ErrTXOptionsInvalidAccessMode, options.AccessMode)
 	}
 	return options, nil
 }
@@ -0,0 +1,116 @@
+package alphasql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sinhashubham95/alpha-sql/internal/fakedriver"
+)
+
+func TestValidateAndDefaultTXOptions(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		options *TXOptions
+		err     error
+		message string
+	}{
+		{name: "default", options: nil},
+		{name: "valid", options: &TXOptions{IsolationLevel: TXIsolationLevelSerializable, AccessMode: TXAccessModeReadOnly}},
+		{
+			name:    "isolation level",
+			options: &TXOptions{IsolationLevel: 99, AccessMode: TXAccessModeReadWrite},
+			err:     ErrTXOptionsInvalidIsolationLevel,
+			message: "invalid transaction isolation level: 99",
+		},
+		{
+			name:    "access mode",
+			options: &TXOptions{AccessMode: "write only"},
+			err:     ErrTXOptionsInvalidAccessMode,
+			message: `invalid transaction access mode: "write only"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			options, err := validateAndDefaultTXOptions(tc.options)
+			if tc.err == nil {
+				if err != nil || options == nil {
+					t.Fatalf("got %v, %v, want valid options", options, err)
+				}
+				return
+			}
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("got %v, want %v", err, tc.err)
+			}
+			if err.Error() != tc.message {
+				t.Fatalf("got message %q, want %q", err.Error(), tc.message)
+			}
+		})
+	}
+}
+
+func TestBeginTXInvalidOptions(t *testing.T) {
+	c := connectFake(t, &fakedriver.Driver{}, nil)
+	_, err := c.BeginTX(context.Background(), &TXOptions{IsolationLevel: -1, AccessMode: TXAccessModeReadWrite})
+	if !errors.Is(err, ErrTXOptionsInvalidIsolationLevel) {
+		t.Fatalf("got %v, want %v", err, ErrTXOptionsInvalidIsolationLevel)
+	}
+	tx, err := c.BeginTX(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("begin after invalid options: %v", err)
+	}
+	_ = tx.Rollback(context.Background())
+}
+
+func TestBeginTXInProgress(t *testing.T) {
+	ctx := context.Background()
+	var begins int
+	c := connectFake(t, &fakedriver.Driver{Begin: func(*fakedriver.Conn) error { begins++; return nil }}, nil)
+
+	tx, err := c.BeginTX(ctx, nil)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	if _, err = c.BeginTX(ctx, nil); !errors.Is(err, ErrTransactionInProgress) {
+		t.Fatalf("got %v beginning a second transaction, want %v", err, ErrTransactionInProgress)
+	}
+	if begins != 1 {
+		t.Fatalf("got %d transactions begun on the driver, want 1", begins)
+	}
+
+	for _, end := range []struct {
+		name string
+		end  func(TX) error
+	}{
+		{"commit", func(tx TX) error { return tx.Commit(ctx) }},
+		{"rollback", func(tx TX) error { return tx.Rollback(ctx) }},
+	} {
+		if err = end.end(tx); err != nil {
+			t.Fatalf("%s: %v", end.name, err)
+		}
+		if tx, err = c.BeginTX(ctx, nil); err != nil {
+			t.Fatalf("begin after %s: %v", end.name, err)
+		}
+	}
+	_ = tx.Rollback(ctx)
+}
+
+func TestBeginTXFailureEndsTransaction(t *testing.T) {
+	ctx := context.Background()
+	fail := true
+	c := connectFake(t, &fakedriver.Driver{Begin: func(*fakedriver.Conn) error {
+		if fail {
+			return errDriver
+		}
+		return nil
+	}}, nil)
+
+	if _, err := c.BeginTX(ctx, nil); !errors.Is(err, errDriver) {
+		t.Fatalf("got %v, want %v", err, errDriver)
+	}
+	fail = false
+	tx, err := c.BeginTX(ctx, nil)
+	if err != nil {
+		t.Fatalf("got %v beginning after a failed begin, want no transaction left in progress", err)
+	}
+	_ = tx.Rollback(ctx)
+}
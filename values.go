@@ -3,10 +3,13 @@ package alphasql
 import (
 	"bytes"
 	"database/sql/driver"
+	"encoding"
 	"errors"
 	"fmt"
+	"io"
 	"reflect"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -109,6 +112,10 @@ func stringConversionError(err error) error {
 // be used as the parent for any cursor values converted from a
 // driver.Rows to a Rows.
 func convertAssignRows(src, dest any) error {
+	if d, ok := dest.(*io.Reader); ok {
+		return convertAssignReader(src, d)
+	}
+
 	// Common cases, without reflect.
 	switch s := src.(type) {
 	case string:
@@ -164,6 +171,18 @@ func convertAssignRows(src, dest any) error {
 		case *time.Time:
 			*d = s
 			return nil
+		case *Date:
+			if d == nil {
+				return ErrNilPointer
+			}
+			*d = DateOf(s)
+			return nil
+		case *TimeOfDay:
+			if d == nil {
+				return ErrNilPointer
+			}
+			*d = TimeOfDayOf(s)
+			return nil
 		case *string:
 			*d = s.Format(time.RFC3339Nano)
 			return nil
@@ -187,6 +206,8 @@ func convertAssignRows(src, dest any) error {
 		}
 	case nil:
 		switch d := dest.(type) {
+		case *time.Time:
+			return errors.New("converting NULL to time.Time is unsupported")
 		case *any:
 			if d == nil {
 				return ErrNilPointer
@@ -257,6 +278,15 @@ func convertAssignRows(src, dest any) error {
 		return ErrNilPointer
 	}
 
+	if u, ok := dest.(encoding.TextUnmarshaler); ok {
+		switch s := src.(type) {
+		case string:
+			return u.UnmarshalText([]byte(s))
+		case []byte:
+			return u.UnmarshalText(s)
+		}
+	}
+
 	if !sv.IsValid() {
 		sv = reflect.ValueOf(src)
 	}
@@ -338,7 +368,49 @@ func convertAssignRows(src, dest any) error {
 			dv.SetString(string(v))
 			return nil
 		}
+	case reflect.Array:
+		if dv.Type().Elem().Kind() != reflect.Uint8 {
+			break
+		}
+		if src == nil {
+			return fmt.Errorf("converting NULL to %s is unsupported", dv.Type())
+		}
+		var b []byte
+		switch v := src.(type) {
+		case string:
+			b = []byte(v)
+		case []byte:
+			b = v
+		default:
+			return ErrRowsUnsupportedScan
+		}
+		if len(b) != dv.Len() {
+			return fmt.Errorf("converting driver.Value of length %d to a %s: length mismatch", len(b), dv.Type())
+		}
+		reflect.Copy(dv, reflect.ValueOf(b))
+		return nil
 	default:
 	}
 	return ErrRowsUnsupportedScan
 }
+
+// convertAssignReader sets dest to a reader of the bytes of src. A streaming value provided by the driver
+// is used as is, while the []byte and string values are copied, as they may be reused by the driver.
+func convertAssignReader(src any, dest *io.Reader) error {
+	if dest == nil {
+		return ErrNilPointer
+	}
+	switch s := src.(type) {
+	case nil:
+		*dest = nil
+	case io.Reader:
+		*dest = s
+	case []byte:
+		*dest = bytes.NewReader(bytes.Clone(s))
+	case string:
+		*dest = strings.NewReader(s)
+	default:
+		return fmt.Errorf("converting driver.Value type %T to a io.Reader: %w", src, ErrRowsUnsupportedScan)
+	}
+	return nil
+}